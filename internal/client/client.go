@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
@@ -133,6 +136,10 @@ func (c *Client) get(endpoint string, params url.Values) (result []byte, err err
 		}
 	}()
 
+	if err := checkRateLimit(resp); err != nil {
+		return nil, err
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -176,6 +183,10 @@ func (c *Client) post(endpoint string, data interface{}) (result []byte, err err
 		}
 	}()
 
+	if err := checkRateLimit(resp); err != nil {
+		return nil, err
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -195,42 +206,261 @@ func (c *Client) post(endpoint string, data interface{}) (result []byte, err err
 
 // Channel represents a Slack channel
 type Channel struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	IsPrivate  bool   `json:"is_private"`
-	IsArchived bool   `json:"is_archived"`
-	Topic      struct {
-		Value string `json:"value"`
-	} `json:"topic"`
-	Purpose struct {
-		Value string `json:"value"`
-	} `json:"purpose"`
-	NumMembers int `json:"num_members"`
+	ID                 string       `json:"id"`
+	Name               string       `json:"name"`
+	NameNormalized     string       `json:"name_normalized,omitempty"`
+	Created            int64        `json:"created,omitempty"`
+	Creator            string       `json:"creator,omitempty"`
+	IsChannel          bool         `json:"is_channel,omitempty"`
+	IsGroup            bool         `json:"is_group,omitempty"`
+	IsIM               bool         `json:"is_im,omitempty"`
+	IsMpIM             bool         `json:"is_mpim,omitempty"`
+	IsPrivate          bool         `json:"is_private"`
+	IsArchived         bool         `json:"is_archived"`
+	IsGeneral          bool         `json:"is_general,omitempty"`
+	IsMember           bool         `json:"is_member,omitempty"`
+	IsShared           bool         `json:"is_shared,omitempty"`
+	IsExtShared        bool         `json:"is_ext_shared,omitempty"`
+	IsOrgShared        bool         `json:"is_org_shared,omitempty"`
+	IsPendingExtShared bool         `json:"is_pending_ext_shared,omitempty"`
+	Topic              ChannelTopic `json:"topic"`
+	Purpose            ChannelTopic `json:"purpose"`
+	NumMembers         int          `json:"num_members"`
+}
+
+// ChannelTopic is a channel's topic or purpose, with who set it and when
+type ChannelTopic struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator,omitempty"`
+	LastSet int64  `json:"last_set,omitempty"`
 }
 
 // User represents a Slack user
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	RealName string `json:"real_name"`
-	IsAdmin  bool   `json:"is_admin"`
-	IsBot    bool   `json:"is_bot"`
-	Profile  struct {
-		Email       string `json:"email"`
-		DisplayName string `json:"display_name"`
-		StatusText  string `json:"status_text"`
-		StatusEmoji string `json:"status_emoji"`
-	} `json:"profile"`
-}
-
-// Message represents a Slack message
+	ID                string      `json:"id"`
+	TeamID            string      `json:"team_id,omitempty"`
+	Name              string      `json:"name"`
+	RealName          string      `json:"real_name"`
+	Deleted           bool        `json:"deleted,omitempty"`
+	IsAdmin           bool        `json:"is_admin"`
+	IsOwner           bool        `json:"is_owner,omitempty"`
+	IsPrimaryOwner    bool        `json:"is_primary_owner,omitempty"`
+	IsRestricted      bool        `json:"is_restricted,omitempty"`
+	IsUltraRestricted bool        `json:"is_ultra_restricted,omitempty"`
+	IsBot             bool        `json:"is_bot"`
+	IsAppUser         bool        `json:"is_app_user,omitempty"`
+	TZ                string      `json:"tz,omitempty"`
+	TZLabel           string      `json:"tz_label,omitempty"`
+	TZOffset          int         `json:"tz_offset,omitempty"`
+	Updated           int64       `json:"updated,omitempty"`
+	Profile           UserProfile `json:"profile"`
+}
+
+// IsGuest reports whether the user is a single- or multi-channel guest
+func (u User) IsGuest() bool {
+	return u.IsRestricted || u.IsUltraRestricted
+}
+
+// UserProfile holds the profile fields of a Slack user
+type UserProfile struct {
+	Email            string `json:"email"`
+	DisplayName      string `json:"display_name"`
+	RealName         string `json:"real_name,omitempty"`
+	FirstName        string `json:"first_name,omitempty"`
+	LastName         string `json:"last_name,omitempty"`
+	Title            string `json:"title,omitempty"`
+	Phone            string `json:"phone,omitempty"`
+	StatusText       string `json:"status_text"`
+	StatusEmoji      string `json:"status_emoji"`
+	StatusExpiration int64  `json:"status_expiration,omitempty"`
+	Image24          string `json:"image_24,omitempty"`
+	Image48          string `json:"image_48,omitempty"`
+	Image72          string `json:"image_72,omitempty"`
+	Image192         string `json:"image_192,omitempty"`
+	Image512         string `json:"image_512,omitempty"`
+	ImageOriginal    string `json:"image_original,omitempty"`
+}
+
+// Message represents a Slack message as returned by conversations.history,
+// conversations.replies and related methods. Fields Slack sends that aren't
+// modelled here are kept in Extra, so JSON output includes everything Slack
+// returned.
 type Message struct {
-	Type       string `json:"type"`
-	User       string `json:"user"`
-	Text       string `json:"text"`
-	TS         string `json:"ts"`
-	ThreadTS   string `json:"thread_ts,omitempty"`
-	ReplyCount int    `json:"reply_count,omitempty"`
+	Type            string            `json:"type"`
+	Subtype         string            `json:"subtype,omitempty"`
+	User            string            `json:"user"`
+	BotID           string            `json:"bot_id,omitempty"`
+	BotProfile      *BotProfile       `json:"bot_profile,omitempty"`
+	Username        string            `json:"username,omitempty"`
+	AppID           string            `json:"app_id,omitempty"`
+	Team            string            `json:"team,omitempty"`
+	Text            string            `json:"text"`
+	TS              string            `json:"ts"`
+	ThreadTS        string            `json:"thread_ts,omitempty"`
+	ParentUserID    string            `json:"parent_user_id,omitempty"`
+	ReplyCount      int               `json:"reply_count,omitempty"`
+	ReplyUsersCount int               `json:"reply_users_count,omitempty"`
+	ReplyUsers      []string          `json:"reply_users,omitempty"`
+	LatestReply     string            `json:"latest_reply,omitempty"`
+	Edited          *Edited           `json:"edited,omitempty"`
+	Reactions       []Reaction        `json:"reactions,omitempty"`
+	Files           []File            `json:"files,omitempty"`
+	Attachments     []Attachment      `json:"attachments,omitempty"`
+	Blocks          []json.RawMessage `json:"blocks,omitempty"`
+	Metadata        *MessageMetadata  `json:"metadata,omitempty"`
+	Permalink       string            `json:"permalink,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a message, keeping unmodelled fields in Extra
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type plain Message
+	extra, err := decodeWithExtra(data, (*plain)(m))
+	m.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a message, including the fields kept in Extra
+func (m Message) MarshalJSON() ([]byte, error) {
+	type plain Message
+	return encodeWithExtra(plain(m), m.Extra)
+}
+
+// BotProfile describes the app that posted a bot message
+type BotProfile struct {
+	ID    string `json:"id"`
+	AppID string `json:"app_id,omitempty"`
+	Name  string `json:"name"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a bot profile, keeping unmodelled fields in Extra
+func (b *BotProfile) UnmarshalJSON(data []byte) error {
+	type plain BotProfile
+	extra, err := decodeWithExtra(data, (*plain)(b))
+	b.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a bot profile, including the fields kept in Extra
+func (b BotProfile) MarshalJSON() ([]byte, error) {
+	type plain BotProfile
+	return encodeWithExtra(plain(b), b.Extra)
+}
+
+// Edited records who last edited a message and when
+type Edited struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// Attachment is a legacy message attachment, as posted by many integrations
+type Attachment struct {
+	Fallback    string            `json:"fallback,omitempty"`
+	Color       string            `json:"color,omitempty"`
+	Pretext     string            `json:"pretext,omitempty"`
+	AuthorName  string            `json:"author_name,omitempty"`
+	AuthorLink  string            `json:"author_link,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	TitleLink   string            `json:"title_link,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Fields      []AttachmentField `json:"fields,omitempty"`
+	ImageURL    string            `json:"image_url,omitempty"`
+	Footer      string            `json:"footer,omitempty"`
+	FromURL     string            `json:"from_url,omitempty"`
+	ServiceName string            `json:"service_name,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes an attachment, keeping unmodelled fields in Extra
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type plain Attachment
+	extra, err := decodeWithExtra(data, (*plain)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes an attachment, including the fields kept in Extra
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type plain Attachment
+	return encodeWithExtra(plain(a), a.Extra)
+}
+
+// AttachmentField is a title/value pair shown in an attachment
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// MessageMetadata is structured data attached to a message by the app that posted it
+type MessageMetadata struct {
+	EventType    string                 `json:"event_type"`
+	EventPayload map[string]interface{} `json:"event_payload"`
+}
+
+// Reaction is an emoji reaction on a message, with the users who added it
+type Reaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users"`
+}
+
+// File represents a file shared in Slack. Fields Slack sends that aren't
+// modelled here are kept in Extra.
+type File struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Title              string `json:"title"`
+	Mimetype           string `json:"mimetype,omitempty"`
+	Filetype           string `json:"filetype"`
+	Size               int64  `json:"size,omitempty"`
+	Mode               string `json:"mode,omitempty"`
+	User               string `json:"user"`
+	Created            int64  `json:"created"`
+	URLPrivate         string `json:"url_private,omitempty"`
+	URLPrivateDownload string `json:"url_private_download,omitempty"`
+	Permalink          string `json:"permalink"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// LocalName returns a safe name for saving the file locally: its name without
+// any directories, or its ID if it has no usable name. slck export saves files
+// under this name and slck import looks for them by it.
+func (f File) LocalName() string {
+	name := filepath.Base(f.Name)
+	if name == "" || name == "." || name == ".." || name == string(filepath.Separator) {
+		return f.ID
+	}
+	return name
+}
+
+// UnmarshalJSON decodes a file, keeping unmodelled fields in Extra
+func (f *File) UnmarshalJSON(data []byte) error {
+	type plain File
+	extra, err := decodeWithExtra(data, (*plain)(f))
+	f.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a file, including the fields kept in Extra
+func (f File) MarshalJSON() ([]byte, error) {
+	type plain File
+	return encodeWithExtra(plain(f), f.Extra)
+}
+
+// PinnedItem represents a message or file pinned to a channel
+type PinnedItem struct {
+	Type      string   `json:"type"`
+	Channel   string   `json:"channel"`
+	Created   int64    `json:"created"`
+	CreatedBy string   `json:"created_by"`
+	Message   *Message `json:"message,omitempty"`
+	File      *File    `json:"file,omitempty"`
 }
 
 // Team represents workspace info
@@ -240,6 +470,14 @@ type Team struct {
 	Domain string `json:"domain"`
 }
 
+// Usergroup represents a Slack user group (e.g. @oncall)
+type Usergroup struct {
+	ID          string `json:"id"`
+	Handle      string `json:"handle"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // SearchMatch represents a message match from search
 type SearchMatch struct {
 	Type    string `json:"type"`
@@ -349,6 +587,12 @@ func (c *Client) ListChannels(types string, excludeArchived bool, limit int) ([]
 	return allChannels, nil
 }
 
+// ListAllChannels returns every channel of the given types, following the
+// cursor through as many pages as it takes
+func (c *Client) ListAllChannels(types string, excludeArchived bool) ([]Channel, error) {
+	return c.ListChannels(types, excludeArchived, math.MaxInt32)
+}
+
 // GetChannelInfo returns channel details
 func (c *Client) GetChannelInfo(channelID string) (*Channel, error) {
 	params := url.Values{}
@@ -419,6 +663,12 @@ func (c *Client) ListUsers(limit int) ([]User, error) {
 	return allUsers, nil
 }
 
+// ListAllUsers returns every user in the workspace, following the cursor
+// through as many pages as it takes
+func (c *Client) ListAllUsers() ([]User, error) {
+	return c.ListUsers(math.MaxInt32)
+}
+
 // GetUserInfo returns user details
 func (c *Client) GetUserInfo(userID string) (*User, error) {
 	params := url.Values{}
@@ -439,10 +689,47 @@ func (c *Client) GetUserInfo(userID string) (*User, error) {
 	return &result.User, nil
 }
 
+// ListUsergroups returns the workspace's user groups
+func (c *Client) ListUsergroups() ([]Usergroup, error) {
+	body, err := c.get("usergroups.list", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Usergroups []Usergroup `json:"usergroups"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Usergroups, nil
+}
+
+// DefaultBlocks creates a Block Kit section block with mrkdwn formatting.
+// This provides a more refined appearance compared to plain text messages,
+// and is what every command that sends or edits text uses by default.
+func DefaultBlocks(text string) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": text,
+			},
+		},
+	}
+}
+
 // SendMessage sends a message to a channel.
 // Text can be empty if blocks are provided (Slack API allows this).
 // The unfurl parameter controls whether link previews are shown (unfurl_links and unfurl_media).
 func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{}, unfurl bool) (*Message, error) {
+	return c.SendMessageWithMetadata(channel, text, threadTS, blocks, unfurl, nil)
+}
+
+// SendMessageWithMetadata sends a message with optional metadata attached.
+func (c *Client) SendMessageWithMetadata(channel, text, threadTS string, blocks []interface{}, unfurl bool, metadata *MessageMetadata) (*Message, error) {
 	data := map[string]interface{}{
 		"channel":      channel,
 		"unfurl_links": unfurl,
@@ -458,7 +745,48 @@ func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{
 	if len(blocks) > 0 {
 		data["blocks"] = blocks
 	}
+	if metadata != nil {
+		data["metadata"] = metadata
+	}
+
+	return c.postMessage(channel, text, data)
+}
 
+// Persona is a name and icon to post a message under instead of the app's
+// own. Posting as a persona needs the chat:write.customize scope.
+type Persona struct {
+	Username  string
+	IconURL   string
+	IconEmoji string
+}
+
+// SendMessageAs sends a message under a persona. Link previews are off, since
+// the message is usually a copy of one posted elsewhere.
+func (c *Client) SendMessageAs(channel, text, threadTS string, persona Persona) (*Message, error) {
+	data := map[string]interface{}{
+		"channel":      channel,
+		"text":         text,
+		"unfurl_links": false,
+		"unfurl_media": false,
+	}
+	if threadTS != "" {
+		data["thread_ts"] = threadTS
+	}
+	if persona.Username != "" {
+		data["username"] = persona.Username
+	}
+	if persona.IconURL != "" {
+		data["icon_url"] = persona.IconURL
+	}
+	if persona.IconEmoji != "" {
+		data["icon_emoji"] = persona.IconEmoji
+	}
+
+	return c.postMessage(channel, text, data)
+}
+
+// postMessage calls chat.postMessage and records the sent message
+func (c *Client) postMessage(channel, text string, data map[string]interface{}) (*Message, error) {
 	body, err := c.post("chat.postMessage", data)
 	if err != nil {
 		return nil, err
@@ -474,12 +802,24 @@ func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{
 	}
 
 	result.Message.TS = result.TS
+
+	sentChannel := result.Channel
+	if sentChannel == "" {
+		sentChannel = channel
+	}
+	c.notifySent(SentItem{Channel: sentChannel, TS: result.TS, Text: text})
+
 	return &result.Message, nil
 }
 
 // UpdateMessage updates an existing message.
 // The unfurl parameter controls whether link previews are shown (unfurl_links and unfurl_media).
 func (c *Client) UpdateMessage(channel, ts, text string, blocks []interface{}, unfurl bool) error {
+	return c.UpdateMessageWithMetadata(channel, ts, text, blocks, unfurl, nil)
+}
+
+// UpdateMessageWithMetadata updates an existing message, replacing its metadata if metadata is non-nil.
+func (c *Client) UpdateMessageWithMetadata(channel, ts, text string, blocks []interface{}, unfurl bool, metadata *MessageMetadata) error {
 	data := map[string]interface{}{
 		"channel":      channel,
 		"ts":           ts,
@@ -490,6 +830,9 @@ func (c *Client) UpdateMessage(channel, ts, text string, blocks []interface{}, u
 	if len(blocks) > 0 {
 		data["blocks"] = blocks
 	}
+	if metadata != nil {
+		data["metadata"] = metadata
+	}
 
 	_, err := c.post("chat.update", data)
 	return err
@@ -515,6 +858,7 @@ func (c *Client) GetChannelHistory(channel string, limit int, oldest, latest str
 	for remaining > 0 {
 		params := url.Values{}
 		params.Set("channel", channel)
+		params.Set("include_all_metadata", "true")
 		// Request up to 200 at a time (Slack recommended max)
 		batchSize := remaining
 		if batchSize > 200 {
@@ -563,8 +907,103 @@ func (c *Client) GetChannelHistory(channel string, limit int, oldest, latest str
 	return allMessages, nil
 }
 
+// GetChannelHistorySince returns every message posted after oldest, following
+// the cursor through as many pages as it takes
+func (c *Client) GetChannelHistorySince(channel, oldest string) ([]Message, error) {
+	return c.GetChannelHistory(channel, math.MaxInt32, oldest, "")
+}
+
+// GetMessage returns a single message. Thread replies don't appear in the
+// channel history, so if ts isn't found there the thread lookup is tried.
+func (c *Client) GetMessage(channel, ts string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("latest", ts)
+	params.Set("inclusive", "true")
+	params.Set("limit", "1")
+	params.Set("include_all_metadata", "true")
+
+	body, err := c.get("conversations.history", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Messages) > 0 && result.Messages[0].TS == ts {
+		return &result.Messages[0], nil
+	}
+
+	params = url.Values{}
+	params.Set("channel", channel)
+	params.Set("ts", ts)
+	params.Set("latest", ts)
+	params.Set("inclusive", "true")
+	params.Set("limit", "1")
+	params.Set("include_all_metadata", "true")
+
+	body, err = c.get("conversations.replies", params)
+	if err != nil {
+		return nil, err
+	}
+	result.Messages = nil
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	// Slack includes the thread parent, so look for the exact message
+	for i := range result.Messages {
+		if result.Messages[i].TS == ts {
+			return &result.Messages[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("message %s not found in %s", ts, channel)
+}
+
+// GetReply returns a single reply in the thread whose parent is threadTS
+func (c *Client) GetReply(channel, threadTS, ts string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("ts", threadTS)
+	params.Set("oldest", ts)
+	params.Set("latest", ts)
+	params.Set("inclusive", "true")
+	params.Set("limit", "2")
+	params.Set("include_all_metadata", "true")
+
+	body, err := c.get("conversations.replies", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	// Slack includes the thread parent, so look for the exact message
+	for i := range result.Messages {
+		if result.Messages[i].TS == ts {
+			return &result.Messages[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("reply %s not found in thread %s", ts, threadTS)
+}
+
 // GetThreadReplies returns replies to a thread (handles pagination to reach requested limit)
 func (c *Client) GetThreadReplies(channel, threadTS string, limit int) ([]Message, error) {
+	return c.GetThreadRepliesSince(channel, threadTS, "", limit)
+}
+
+// GetThreadRepliesSince returns thread messages posted after oldest.
+// An empty oldest returns the whole thread. Slack always includes the parent message.
+func (c *Client) GetThreadRepliesSince(channel, threadTS, oldest string, limit int) ([]Message, error) {
 	var allMessages []Message
 	cursor := ""
 	remaining := limit
@@ -573,12 +1012,16 @@ func (c *Client) GetThreadReplies(channel, threadTS string, limit int) ([]Messag
 		params := url.Values{}
 		params.Set("channel", channel)
 		params.Set("ts", threadTS)
+		params.Set("include_all_metadata", "true")
 		// Request up to 200 at a time (Slack recommended max)
 		batchSize := remaining
 		if batchSize > 200 {
 			batchSize = 200
 		}
 		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if oldest != "" {
+			params.Set("oldest", oldest)
+		}
 		if cursor != "" {
 			params.Set("cursor", cursor)
 		}
@@ -639,6 +1082,133 @@ func (c *Client) RemoveReaction(channel, timestamp, name string) error {
 	return err
 }
 
+// GetReactions returns a message with the full list of its reactions
+func (c *Client) GetReactions(channel, timestamp string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("timestamp", timestamp)
+	params.Set("full", "true")
+
+	body, err := c.get("reactions.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Message Message `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Message, nil
+}
+
+// ReactedItem is an item a user has reacted to
+type ReactedItem struct {
+	Type    string   `json:"type"`
+	Channel string   `json:"channel,omitempty"`
+	Message *Message `json:"message,omitempty"`
+	File    *File    `json:"file,omitempty"`
+}
+
+// ListReactions returns up to limit items the given user has reacted to, most
+// recent first. If user is empty, the authenticated user's reactions are listed.
+func (c *Client) ListReactions(user string, limit int) ([]ReactedItem, error) {
+	var allItems []ReactedItem
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		if user != "" {
+			params.Set("user", user)
+		}
+		params.Set("full", "true")
+		// Request up to 200 at a time (Slack recommended max)
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get("reactions.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Items            []ReactedItem `json:"items"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allItems = append(allItems, result.Items...)
+		remaining -= len(result.Items)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	// Trim to exact limit if we got more
+	if len(allItems) > limit {
+		allItems = allItems[:limit]
+	}
+
+	return allItems, nil
+}
+
+// AddPin pins a message to a channel
+func (c *Client) AddPin(channel, timestamp string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": timestamp,
+	}
+
+	_, err := c.post("pins.add", data)
+	return err
+}
+
+// RemovePin unpins a message from a channel
+func (c *Client) RemovePin(channel, timestamp string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": timestamp,
+	}
+
+	_, err := c.post("pins.remove", data)
+	return err
+}
+
+// ListPins returns the items pinned to a channel
+func (c *Client) ListPins(channel string) ([]PinnedItem, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+
+	body, err := c.get("pins.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []PinnedItem `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
 // GetTeamInfo returns workspace info
 func (c *Client) GetTeamInfo() (*Team, error) {
 	body, err := c.get("team.info", nil)
@@ -758,6 +1328,109 @@ func (c *Client) InviteToChannel(channel string, users []string) error {
 	return err
 }
 
+// --- Bookmark Methods ---
+
+// Bookmark represents a link in a channel's bookmark bar
+type Bookmark struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel_id"`
+	Title       string `json:"title"`
+	Link        string `json:"link"`
+	Emoji       string `json:"emoji,omitempty"`
+	Type        string `json:"type"`
+	DateCreated int64  `json:"date_created,omitempty"`
+	DateUpdated int64  `json:"date_updated,omitempty"`
+}
+
+// ListBookmarks returns the bookmarks in a channel
+func (c *Client) ListBookmarks(channel string) ([]Bookmark, error) {
+	params := url.Values{}
+	params.Set("channel_id", channel)
+
+	body, err := c.get("bookmarks.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bookmarks []Bookmark `json:"bookmarks"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Bookmarks, nil
+}
+
+// AddBookmark adds a bookmark to a channel. The emoji is optional.
+func (c *Client) AddBookmark(channel, title, bookmarkType, link, emoji string) (*Bookmark, error) {
+	data := map[string]interface{}{
+		"channel_id": channel,
+		"title":      title,
+		"type":       bookmarkType,
+		"link":       link,
+	}
+	if emoji != "" {
+		data["emoji"] = emoji
+	}
+
+	body, err := c.post("bookmarks.add", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bookmark Bookmark `json:"bookmark"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Bookmark, nil
+}
+
+// EditBookmark updates a bookmark. Empty title, link or emoji values are left unchanged.
+func (c *Client) EditBookmark(channel, bookmarkID, title, link, emoji string) (*Bookmark, error) {
+	data := map[string]interface{}{
+		"channel_id":  channel,
+		"bookmark_id": bookmarkID,
+	}
+	if title != "" {
+		data["title"] = title
+	}
+	if link != "" {
+		data["link"] = link
+	}
+	if emoji != "" {
+		data["emoji"] = emoji
+	}
+
+	body, err := c.post("bookmarks.edit", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bookmark Bookmark `json:"bookmark"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Bookmark, nil
+}
+
+// RemoveBookmark removes a bookmark from a channel
+func (c *Client) RemoveBookmark(channel, bookmarkID string) error {
+	data := map[string]interface{}{
+		"channel_id":  channel,
+		"bookmark_id": bookmarkID,
+	}
+
+	_, err := c.post("bookmarks.remove", data)
+	return err
+}
+
 // --- File Upload Methods ---
 
 // UploadURLResponse contains the response from files.getUploadURLExternal
@@ -807,6 +1480,39 @@ func (c *Client) UploadFileToURL(uploadURL string, data io.Reader) error {
 	return nil
 }
 
+// DownloadFile writes the file at a url_private or url_private_download URL to w
+func (c *Client) DownloadFile(fileURL string, w io.Writer) (err error) {
+	req, err := http.NewRequest("GET", fileURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if err := checkRateLimit(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+	// Slack serves its sign-in page instead of the file when the token can't read it
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		return fmt.Errorf("download returned a web page instead of the file (does the token have files:read?)")
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
 // CompleteUploadExternalFile represents a file in the complete upload request
 type CompleteUploadExternalFile struct {
 	ID    string `json:"id"`
@@ -826,7 +1532,23 @@ func (c *Client) CompleteUploadExternal(files []CompleteUploadExternalFile, chan
 		data["initial_comment"] = initialComment
 	}
 
-	_, err := c.post("files.completeUploadExternal", data)
+	if _, err := c.post("files.completeUploadExternal", data); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		c.notifySent(SentItem{Channel: channelID, FileID: f.ID, Text: initialComment})
+	}
+	return nil
+}
+
+// DeleteFile deletes a file
+func (c *Client) DeleteFile(fileID string) error {
+	data := map[string]interface{}{
+		"file": fileID,
+	}
+
+	_, err := c.post("files.delete", data)
 	return err
 }
 
@@ -915,3 +1637,79 @@ func (c *Client) SearchAll(query string, count, page int, sort, sortDir string,
 	result.Query = query
 	return &result, nil
 }
+
+// --- Reminder Methods (require user token) ---
+
+// Reminder represents a Slack reminder
+type Reminder struct {
+	ID         string `json:"id"`
+	Creator    string `json:"creator"`
+	User       string `json:"user"`
+	Text       string `json:"text"`
+	Recurring  bool   `json:"recurring"`
+	Time       int64  `json:"time,omitempty"`
+	CompleteTS int64  `json:"complete_ts,omitempty"`
+}
+
+// AddReminder creates a reminder at the given Unix time. If user is empty, the
+// reminder is for the authenticated user.
+func (c *Client) AddReminder(text string, at int64, user string) (*Reminder, error) {
+	data := map[string]interface{}{
+		"text": text,
+		"time": fmt.Sprintf("%d", at),
+	}
+	if user != "" {
+		data["user"] = user
+	}
+
+	body, err := c.post("reminders.add", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Reminder Reminder `json:"reminder"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Reminder, nil
+}
+
+// ListReminders returns the reminders created by or for the authenticated user
+func (c *Client) ListReminders() ([]Reminder, error) {
+	body, err := c.get("reminders.list", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Reminders []Reminder `json:"reminders"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Reminders, nil
+}
+
+// CompleteReminder marks a reminder as complete
+func (c *Client) CompleteReminder(reminderID string) error {
+	data := map[string]interface{}{
+		"reminder": reminderID,
+	}
+
+	_, err := c.post("reminders.complete", data)
+	return err
+}
+
+// DeleteReminder deletes a reminder
+func (c *Client) DeleteReminder(reminderID string) error {
+	data := map[string]interface{}{
+		"reminder": reminderID,
+	}
+
+	_, err := c.post("reminders.delete", data)
+	return err
+}
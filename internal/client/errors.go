@@ -1,10 +1,83 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// defaultRetryAfter is used when a rate limited response has no Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// MaxRateLimitRetries is how many times bulk commands retry a rate limited call
+const MaxRateLimitRetries = 10
+
+// RateLimitError is returned when Slack responds with HTTP 429 Too Many Requests.
+type RateLimitError struct {
+	// RetryAfter is how long Slack asked us to wait before the next request
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return "slack API error: ratelimited"
+}
+
+// IsRateLimited reports whether err is a rate limit error and, if so, how long to wait.
+func IsRateLimited(err error) (time.Duration, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		return rl.RetryAfter, true
+	}
+	return 0, false
+}
+
+// SleepContext waits for d, such as a rate limit's RetryAfter, returning
+// false if ctx was cancelled first
+func SleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// RetryRateLimited runs fn, waiting out and retrying rate limited attempts up to
+// retries times. It returns ctx's error if ctx is cancelled first.
+func RetryRateLimited(ctx context.Context, retries int, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := fn()
+		wait, limited := IsRateLimited(err)
+		if !limited || attempt >= retries {
+			return err
+		}
+		if !SleepContext(ctx, wait) {
+			return ctx.Err()
+		}
+	}
+}
+
+// checkRateLimit returns a RateLimitError if the response is HTTP 429.
+func checkRateLimit(resp *http.Response) error {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	retryAfter := defaultRetryAfter
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+		retryAfter = time.Duration(secs) * time.Second
+	}
+	return &RateLimitError{RetryAfter: retryAfter}
+}
+
 // errorHints maps Slack API error codes to helpful hints.
 var errorHints = map[string]string{
 	"channel_not_found":    "Verify the channel ID is correct. Use 'slck channels list' to find channel IDs.",
@@ -26,6 +99,10 @@ var errorHints = map[string]string{
 	"is_archived":          "Cannot perform this action on an archived channel.",
 	"too_many_attachments": "Message has too many attachments. Reduce and try again.",
 	"msg_too_long":         "Message is too long. Maximum is 40,000 characters.",
+	"already_pinned":       "This message is already pinned.",
+	"no_pin":               "This message is not pinned.",
+	"not_pinnable":         "This message cannot be pinned.",
+	"invalid_blocks":       "Blocks are invalid. Section text is limited to 3,000 characters.",
 }
 
 // WrapError wraps a Slack API error with context and a helpful hint if available.
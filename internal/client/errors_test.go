@@ -1,9 +1,13 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -122,3 +126,75 @@ func TestWrapError_OperationContext(t *testing.T) {
 	assert.Contains(t, errStr, "archive channel C123")
 	assert.Contains(t, errStr, "already_archived")
 }
+
+func TestClient_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "test-token", nil)
+	_, err := c.GetChannelHistory("C123", 10, "", "")
+	require.Error(t, err)
+
+	wait, ok := IsRateLimited(err)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	// Wrapped errors keep the rate limit details and the hint
+	wrapped := WrapError("get history", err)
+	wait, ok = IsRateLimited(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+	assert.Contains(t, wrapped.Error(), "Wait a moment")
+}
+
+func TestIsRateLimited_OtherErrors(t *testing.T) {
+	_, ok := IsRateLimited(errors.New("slack API error: channel_not_found"))
+	assert.False(t, ok)
+	_, ok = IsRateLimited(nil)
+	assert.False(t, ok)
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	calls := 0
+	err := RetryRateLimited(context.Background(), 3, func() error {
+		calls++
+		if calls < 3 {
+			return &RateLimitError{}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Gives up after the retries, returning the rate limit error
+	calls = 0
+	err = RetryRateLimited(context.Background(), 2, func() error {
+		calls++
+		return &RateLimitError{}
+	})
+	_, ok := IsRateLimited(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, calls)
+
+	// Other errors aren't retried
+	calls = 0
+	err = RetryRateLimited(context.Background(), 2, func() error {
+		calls++
+		return errors.New("slack API error: channel_not_found")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// A cancelled context stops the wait
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = RetryRateLimited(ctx, 2, func() error {
+		t.Error("fn is not called once ctx is cancelled")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
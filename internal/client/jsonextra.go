@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFields caches the JSON keys of struct types, keyed by reflect.Type
+var knownFields sync.Map
+
+// decodeWithExtra unmarshals data into v, a pointer to a struct, and returns
+// the fields of data that v has no field for. It returns nil if there are none.
+func decodeWithExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	for key := range all {
+		if known[key] {
+			delete(all, key)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// encodeWithExtra marshals v and adds the extra fields. Fields of v take
+// precedence over extra fields with the same key.
+func encodeWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := merged[key]; !ok {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// MarshalWithFields marshals v and sets the given fields alongside its own,
+// replacing any it already has. Use it to flatten a Message into a larger
+// JSON object: embedding can't, because Message has its own MarshalJSON,
+// which would hide the other fields.
+func MarshalWithFields(v interface{}, fields map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		if merged[key], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// jsonFields returns the JSON keys of a struct type's fields
+func jsonFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFields.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			for key := range jsonFields(f.Type) {
+				fields[key] = true
+			}
+		case name == "":
+			fields[f.Name] = true
+		default:
+			fields[name] = true
+		}
+	}
+
+	knownFields.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Code spans, code blocks and existing <...> entities are left untouched
+	protectedRegex = regexp.MustCompile("```[\\s\\S]*?```|`[^`\\n]*`|<[^>\\n]*>")
+
+	// @name, @name@example.com or #channel, not preceded by a word character
+	// (so bob@example.com isn't read as @example.com)
+	mentionTokenRegex = regexp.MustCompile(`(^|[^\w&/<])([@#])([A-Za-z0-9][\w.\-]*(?:@[\w\-]+(?:\.[\w\-]+)+)?)`)
+
+	// A bare email address
+	emailRegex = regexp.MustCompile(`(^|[^\w.@<])([\w.+\-]+@[\w\-]+(?:\.[\w\-]+)+)`)
+)
+
+// specialMentions maps broadcast handles to their Slack syntax
+var specialMentions = map[string]string{
+	"here":     "<!here>",
+	"channel":  "<!channel>",
+	"everyone": "<!everyone>",
+}
+
+// MentionEncoder rewrites human-written mentions (@alice, #deploys, @oncall,
+// alice@example.com) into Slack's <@U123>, <#C123> and <!subteam^S123>
+// syntax. Users, channels and user groups are each listed at most once.
+type MentionEncoder struct {
+	client   *Client
+	users    []User
+	channels []Channel
+	groups   []Usergroup
+	loaded   map[string]bool
+}
+
+// NewMentionEncoder creates an encoder backed by the given client.
+func NewMentionEncoder(c *Client) *MentionEncoder {
+	return &MentionEncoder{
+		client: c,
+		loaded: make(map[string]bool),
+	}
+}
+
+// Encode returns text with mentions rewritten, along with the @ and #
+// mentions that couldn't be resolved. Bare email addresses that don't belong
+// to a user are left as they are and aren't reported. Code and existing
+// <...> entities are not changed.
+func (e *MentionEncoder) Encode(text string) (string, []string, error) {
+	var out strings.Builder
+	var unresolved []string
+
+	last := 0
+	for _, loc := range protectedRegex.FindAllStringIndex(text, -1) {
+		encoded, missing, err := e.encodePlain(text[last:loc[0]])
+		if err != nil {
+			return "", nil, err
+		}
+		out.WriteString(encoded)
+		out.WriteString(text[loc[0]:loc[1]])
+		unresolved = append(unresolved, missing...)
+		last = loc[1]
+	}
+	encoded, missing, err := e.encodePlain(text[last:])
+	if err != nil {
+		return "", nil, err
+	}
+	out.WriteString(encoded)
+	unresolved = append(unresolved, missing...)
+
+	return out.String(), unresolved, nil
+}
+
+// encodePlain rewrites mentions in text that contains no code or entities
+func (e *MentionEncoder) encodePlain(text string) (string, []string, error) {
+	var unresolved []string
+	var firstErr error
+
+	text = emailRegex.ReplaceAllStringFunc(text, func(match string) string {
+		sub := emailRegex.FindStringSubmatch(match)
+		id, err := e.lookupUser(sub[2])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if id == "" {
+			return match
+		}
+		return sub[1] + "<@" + id + ">"
+	})
+
+	text = mentionTokenRegex.ReplaceAllStringFunc(text, func(match string) string {
+		sub := mentionTokenRegex.FindStringSubmatch(match)
+		prefix, sigil, name := sub[1], sub[2], sub[3]
+
+		// Sentence punctuation isn't part of the name
+		trimmed := strings.TrimRight(name, ".-")
+		suffix := name[len(trimmed):]
+		name = trimmed
+
+		// #123 is usually an issue number, not a channel
+		if strings.Trim(name, "0123456789") == "" {
+			return match
+		}
+
+		var encoded string
+		var err error
+		if sigil == "#" {
+			encoded, err = e.encodeChannel(name)
+		} else {
+			encoded, err = e.encodeAt(name)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if encoded == "" {
+			unresolved = append(unresolved, sigil+name)
+			return match
+		}
+		return prefix + encoded + suffix
+	})
+
+	if firstErr != nil {
+		return "", nil, firstErr
+	}
+	return text, unresolved, nil
+}
+
+// encodeAt resolves @name to a broadcast, user or user group mention
+func (e *MentionEncoder) encodeAt(name string) (string, error) {
+	if special, ok := specialMentions[strings.ToLower(name)]; ok {
+		return special, nil
+	}
+
+	id, err := e.lookupUser(name)
+	if err != nil {
+		return "", err
+	}
+	if id != "" {
+		return "<@" + id + ">", nil
+	}
+
+	if !e.loaded["groups"] {
+		e.groups, err = e.client.ListUsergroups()
+		if err != nil {
+			return "", WrapError("list user groups", err)
+		}
+		e.loaded["groups"] = true
+	}
+	for _, g := range e.groups {
+		if strings.EqualFold(g.Handle, name) {
+			return "<!subteam^" + g.ID + ">", nil
+		}
+	}
+	return "", nil
+}
+
+// encodeChannel resolves #name to a channel mention
+func (e *MentionEncoder) encodeChannel(name string) (string, error) {
+	if IsChannelID(name) {
+		return "<#" + name + ">", nil
+	}
+
+	if !e.loaded["channels"] {
+		var err error
+		e.channels, err = e.client.ListAllChannels("public_channel,private_channel", false)
+		if err != nil {
+			return "", WrapError("list channels", err)
+		}
+		e.loaded["channels"] = true
+	}
+	for _, ch := range e.channels {
+		if strings.EqualFold(ch.Name, name) {
+			return "<#" + ch.ID + ">", nil
+		}
+	}
+	return "", nil
+}
+
+// lookupUser returns the ID of the user with the given ID, handle, display
+// name or email, or "" if there is none
+func (e *MentionEncoder) lookupUser(name string) (string, error) {
+	if IsUserID(name) {
+		return name, nil
+	}
+
+	if !e.loaded["users"] {
+		var err error
+		e.users, err = e.client.ListAllUsers()
+		if err != nil {
+			return "", WrapError("list users", err)
+		}
+		e.loaded["users"] = true
+	}
+
+	// Prefer an exact handle match, as ResolveUser does
+	for _, u := range e.users {
+		if strings.EqualFold(u.Name, name) {
+			return u.ID, nil
+		}
+	}
+	for _, u := range e.users {
+		if strings.EqualFold(u.Profile.DisplayName, name) || strings.EqualFold(u.Profile.Email, name) {
+			return u.ID, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newMentionTestServer(t *testing.T, calls map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+
+		var resp map[string]interface{}
+		switch r.URL.Path {
+		case "/users.list":
+			resp = map[string]interface{}{"ok": true, "members": []map[string]interface{}{
+				{"id": "U001", "name": "alice", "profile": map[string]interface{}{"email": "alice@example.com"}},
+				{"id": "U002", "name": "bob.smith", "profile": map[string]interface{}{"display_name": "Bobby"}},
+			}}
+		case "/conversations.list":
+			resp = map[string]interface{}{"ok": true, "channels": []map[string]interface{}{
+				{"id": "C001", "name": "deploys"},
+			}}
+		case "/usergroups.list":
+			resp = map[string]interface{}{"ok": true, "usergroups": []map[string]interface{}{
+				{"id": "S001", "handle": "oncall", "name": "On-call"},
+			}}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestMentionEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		want           string
+		wantUnresolved []string
+	}{
+		{"handle", "@alice please check #deploys.", "<@U001> please check <#C001>.", nil},
+		{"display name and dotted handle", "@Bobby and @bob.smith", "<@U002> and <@U002>", nil},
+		{"user group", "cc @oncall", "cc <!subteam^S001>", nil},
+		{"broadcast", "@here heads up", "<!here> heads up", nil},
+		{"email forms", "ask alice@example.com or @alice@example.com", "ask <@U001> or <@U001>", nil},
+		{"unknown email left alone", "mail help@example.org", "mail help@example.org", nil},
+		{"ids", "@U123ABC in #C123ABC", "<@U123ABC> in <#C123ABC>", nil},
+		{"issue number", "fixes #123", "fixes #123", nil},
+		{"url fragment", "see https://example.com/page#deploys", "see https://example.com/page#deploys", nil},
+		{"code untouched", "run `@alice #deploys` then ```\n@alice\n``` @alice", "run `@alice #deploys` then ```\n@alice\n``` <@U001>", nil},
+		{"existing entities untouched", "<@U001> <#C001|deploys> <https://x.com|@alice>", "<@U001> <#C001|deploys> <https://x.com|@alice>", nil},
+		{"unresolved", "@nobody in #nowhere", "@nobody in #nowhere", []string{"@nobody", "#nowhere"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMentionTestServer(t, map[string]int{})
+			defer server.Close()
+
+			enc := NewMentionEncoder(NewWithConfig(server.URL, "test-token", nil))
+			got, unresolved, err := enc.Encode(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if !reflect.DeepEqual(unresolved, tt.wantUnresolved) {
+				t.Errorf("unresolved = %v, want %v", unresolved, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestMentionEncoder_ListsOnce(t *testing.T) {
+	calls := map[string]int{}
+	server := newMentionTestServer(t, calls)
+	defer server.Close()
+
+	enc := NewMentionEncoder(NewWithConfig(server.URL, "test-token", nil))
+	if _, _, err := enc.Encode("@alice @oncall #deploys @oncall #deploys"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := enc.Encode("@alice again"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{"/users.list", "/conversations.list", "/usergroups.list"} {
+		if calls[path] != 1 {
+			t.Errorf("expected 1 call to %s, got %d", path, calls[path])
+		}
+	}
+}
+
+func TestMentionEncoder_NoLookupsWithoutMentions(t *testing.T) {
+	calls := map[string]int{}
+	server := newMentionTestServer(t, calls)
+	defer server.Close()
+
+	enc := NewMentionEncoder(NewWithConfig(server.URL, "test-token", nil))
+	got, _, err := enc.Encode("nothing to see here")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "nothing to see here" || len(calls) != 0 {
+		t.Errorf("expected no changes and no calls, got %q and %v", got, calls)
+	}
+}
+
+func TestMentionEncoder_SearchesEveryPage(t *testing.T) {
+	// Five full pages of other users and channels, then the ones mentioned
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, last := "members", map[string]interface{}{"id": "U999999", "name": "zoe"}
+		if r.URL.Path == "/conversations.list" {
+			key, last = "channels", map[string]interface{}{"id": "C999999", "name": "zebras"}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		resp := map[string]interface{}{"ok": true}
+		if page < 5 {
+			var items []map[string]interface{}
+			for i := 0; i < 200; i++ {
+				items = append(items, map[string]interface{}{"id": fmt.Sprintf("X%d", page*200+i), "name": fmt.Sprintf("other%d", page*200+i)})
+			}
+			resp[key] = items
+			resp["response_metadata"] = map[string]string{"next_cursor": strconv.Itoa(page + 1)}
+		} else {
+			resp[key] = []map[string]interface{}{last}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	enc := NewMentionEncoder(NewWithConfig(server.URL, "test-token", nil))
+	got, unresolved, err := enc.Encode("@zoe see #zebras")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "<@U999999> see <#C999999>" || len(unresolved) != 0 {
+		t.Errorf("got %q with unresolved %v", got, unresolved)
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxCachedPermalinks caps the permalink cache file; the oldest entries are dropped first
+const maxCachedPermalinks = 5000
+
+// permalinkEntry is a cached permalink and when it was looked up
+type permalinkEntry struct {
+	URL      string    `json:"url"`
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// permalinkCache remembers permalinks, which never change, so each message's
+// link is only looked up once. It's shared by all clients in the process.
+type permalinkCache struct {
+	mu      sync.Mutex
+	path    string
+	loaded  bool
+	dirty   bool
+	entries map[string]permalinkEntry
+}
+
+var permalinks = &permalinkCache{entries: make(map[string]permalinkEntry)}
+
+// SetPermalinkCacheFile makes permalink lookups persist across runs in the
+// file at path. The file is read on the first lookup and written by
+// SavePermalinkCache. Without it, permalinks are only cached in memory.
+func SetPermalinkCacheFile(path string) {
+	permalinks.mu.Lock()
+	defer permalinks.mu.Unlock()
+	permalinks.path = path
+	permalinks.loaded = false
+}
+
+// SavePermalinkCache writes permalinks looked up since the cache was loaded
+// to the cache file, if one is set
+func SavePermalinkCache() error {
+	permalinks.mu.Lock()
+	defer permalinks.mu.Unlock()
+	if permalinks.path == "" || !permalinks.dirty {
+		return nil
+	}
+
+	permalinks.load() // Keep entries saved by other runs
+	permalinks.prune()
+	if err := os.MkdirAll(filepath.Dir(permalinks.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(permalinks.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := permalinks.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, permalinks.path); err != nil {
+		return err
+	}
+	permalinks.dirty = false
+	return nil
+}
+
+// GetPermalink returns the permalink of the message at ts in channel
+func (c *Client) GetPermalink(channel, ts string) (string, error) {
+	key := channel + "/" + ts
+	if link, ok := permalinks.get(key); ok {
+		return link, nil
+	}
+
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("message_ts", ts)
+
+	body, err := c.get("chat.getPermalink", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Permalink string `json:"permalink"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if result.Permalink == "" {
+		return "", fmt.Errorf("no permalink returned for message %s", ts)
+	}
+
+	permalinks.put(key, result.Permalink)
+	return result.Permalink, nil
+}
+
+func (p *permalinkCache) get(key string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.load()
+	entry, ok := p.entries[key]
+	return entry.URL, ok
+}
+
+func (p *permalinkCache) put(key, link string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries[key] = permalinkEntry{URL: link, CachedAt: time.Now()}
+	p.dirty = true
+}
+
+// load reads the cache file once. A missing or unreadable file just means
+// links are looked up again, so errors are ignored.
+func (p *permalinkCache) load() {
+	if p.loaded || p.path == "" {
+		return
+	}
+	p.loaded = true
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return
+	}
+	var stored map[string]permalinkEntry
+	if json.Unmarshal(data, &stored) != nil {
+		return
+	}
+	for key, entry := range stored {
+		if _, ok := p.entries[key]; !ok {
+			p.entries[key] = entry
+		}
+	}
+}
+
+// prune drops the oldest entries beyond maxCachedPermalinks
+func (p *permalinkCache) prune() {
+	if len(p.entries) <= maxCachedPermalinks {
+		return
+	}
+	keys := make([]string, 0, len(p.entries))
+	for key := range p.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return p.entries[keys[i]].CachedAt.After(p.entries[keys[j]].CachedAt)
+	})
+	for _, key := range keys[maxCachedPermalinks:] {
+		delete(p.entries, key)
+	}
+}
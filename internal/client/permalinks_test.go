@@ -0,0 +1,60 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_GetPermalink_Caches(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/chat.getPermalink" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("message_ts") != "1700000000.000200" {
+			t.Errorf("unexpected message_ts %s", r.URL.Query().Get("message_ts"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"channel":"C900","permalink":"https://acme.slack.com/archives/C900/p1700000000000200"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "permalinks.json")
+	SetPermalinkCacheFile(path)
+	defer SetPermalinkCacheFile("")
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	for i := 0; i < 2; i++ {
+		link, err := client.GetPermalink("C900", "1700000000.000200")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if link != "https://acme.slack.com/archives/C900/p1700000000000200" {
+			t.Errorf("unexpected permalink %q", link)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+
+	if err := SavePermalinkCache(); err != nil {
+		t.Fatalf("unexpected error saving cache: %v", err)
+	}
+
+	// A new run starts with an empty memory cache and reads the file
+	permalinks.mu.Lock()
+	permalinks.entries = make(map[string]permalinkEntry)
+	permalinks.mu.Unlock()
+	SetPermalinkCacheFile(path)
+
+	if _, err := client.GetPermalink("C900", "1700000000.000200"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the saved permalink to be used, got %d API calls", calls)
+	}
+}
@@ -22,6 +22,16 @@ func NewUserResolver(c *Client) *UserResolver {
 	}
 }
 
+// NewStaticUserResolver creates a resolver that only knows the given names
+// (user ID to display name) and never calls the API, for offline use.
+func NewStaticUserResolver(names map[string]string) *UserResolver {
+	cache := make(map[string]string, len(names))
+	for id, name := range names {
+		cache[id] = name
+	}
+	return &UserResolver{cache: cache}
+}
+
 // Resolve returns a display name for the given user ID.
 // It returns the ID unchanged if the lookup fails.
 func (r *UserResolver) Resolve(userID string) string {
@@ -36,6 +46,10 @@ func (r *UserResolver) Resolve(userID string) string {
 	}
 	r.mu.Unlock()
 
+	if r.client == nil {
+		return userID
+	}
+
 	user, err := r.client.GetUserInfo(userID)
 	if err != nil {
 		return userID
@@ -59,6 +73,22 @@ func (r *UserResolver) Resolve(userID string) string {
 	return name
 }
 
+// Author returns a display name for a message's author. Bot and integration
+// messages often have no user, so their bot name is used instead.
+func (r *UserResolver) Author(m Message) string {
+	switch {
+	case m.User != "":
+		return r.Resolve(m.User)
+	case m.Username != "":
+		return m.Username
+	case m.BotProfile != nil && m.BotProfile.Name != "":
+		return m.BotProfile.Name
+	case m.BotID != "":
+		return m.BotID
+	}
+	return "unknown"
+}
+
 // ResolveMentions replaces <@UXXXXX> mentions in text with display names.
 func (r *UserResolver) ResolveMentions(text string) string {
 	return mentionRegex.ReplaceAllStringFunc(text, func(match string) string {
@@ -69,3 +99,48 @@ func (r *UserResolver) ResolveMentions(text string) string {
 		return "@" + r.Resolve(sub[1])
 	})
 }
+
+// ChannelResolver resolves Slack channel IDs to names with caching.
+type ChannelResolver struct {
+	client *Client
+	cache  map[string]string
+	mu     sync.Mutex
+}
+
+// NewChannelResolver creates a resolver backed by the given client.
+func NewChannelResolver(c *Client) *ChannelResolver {
+	return &ChannelResolver{
+		client: c,
+		cache:  make(map[string]string),
+	}
+}
+
+// Resolve returns the name of the given channel.
+// It returns the ID unchanged if the lookup fails or the channel has no name.
+func (r *ChannelResolver) Resolve(channelID string) string {
+	if channelID == "" {
+		return channelID
+	}
+
+	r.mu.Lock()
+	if name, ok := r.cache[channelID]; ok {
+		r.mu.Unlock()
+		return name
+	}
+	r.mu.Unlock()
+
+	if r.client == nil {
+		return channelID
+	}
+
+	name := channelID
+	if ch, err := r.client.GetChannelInfo(channelID); err == nil && ch.Name != "" {
+		name = ch.Name
+	}
+
+	r.mu.Lock()
+	r.cache[channelID] = name
+	r.mu.Unlock()
+
+	return name
+}
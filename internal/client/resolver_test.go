@@ -122,3 +122,25 @@ func TestUserResolver_ResolveMentions(t *testing.T) {
 		})
 	}
 }
+
+func TestUserResolver_Author_BotMessages(t *testing.T) {
+	r := NewUserResolver(nil)
+
+	assert.Equal(t, "deploybot", r.Author(Message{Username: "deploybot", BotID: "B1"}))
+	assert.Equal(t, "CI", r.Author(Message{BotProfile: &BotProfile{Name: "CI"}}))
+	assert.Equal(t, "B1", r.Author(Message{BotID: "B1"}))
+	assert.Equal(t, "unknown", r.Author(Message{}))
+}
+
+func TestStaticUserResolver(t *testing.T) {
+	r := NewStaticUserResolver(map[string]string{"U001": "alice"})
+
+	assert.Equal(t, "alice", r.Resolve("U001"))
+	assert.Equal(t, "U999", r.Resolve("U999"), "unknown users aren't looked up")
+}
+
+func TestChannelResolver_NoClient(t *testing.T) {
+	r := NewChannelResolver(nil)
+	assert.Equal(t, "C123", r.Resolve("C123"))
+	assert.Equal(t, "", r.Resolve(""))
+}
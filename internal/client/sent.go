@@ -0,0 +1,29 @@
+package client
+
+import "github.com/open-cli-collective/slack-chat-api/internal/keychain"
+
+// SentItem describes a message or file posted through the client
+type SentItem struct {
+	Channel string
+	TS      string // Message timestamp; empty for files
+	FileID  string // File ID; empty for messages
+	Text    string
+	AsUser  bool // Whether it was posted with the user token
+}
+
+var sentHook func(SentItem)
+
+// SetSentHook registers fn to be called after every message or file the
+// client posts. Pass nil to remove the hook.
+func SetSentHook(fn func(SentItem)) {
+	sentHook = fn
+}
+
+// notifySent reports a posted item to the registered hook, if any
+func (c *Client) notifySent(item SentItem) {
+	if sentHook == nil {
+		return
+	}
+	item.AsUser = keychain.DetectTokenType(c.token) == "user"
+	sentHook(item)
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CompareTimestamps compares two Slack timestamps numerically, returning -1, 0 or 1
+func CompareTimestamps(a, b string) int {
+	aSec, aFrac, _ := strings.Cut(a, ".")
+	bSec, bFrac, _ := strings.Cut(b, ".")
+	if len(aSec) != len(bSec) {
+		if len(aSec) < len(bSec) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(aSec+"."+aFrac, bSec+"."+bFrac)
+}
+
+// Chronological returns messages sorted oldest first (Slack returns history newest first)
+func Chronological(messages []Message) []Message {
+	sorted := make([]Message, len(messages))
+	copy(sorted, messages)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return CompareTimestamps(sorted[i].TS, sorted[j].TS) < 0
+	})
+	return sorted
+}
+
+// TimestampFromTime formats t as a Slack timestamp
+func TimestampFromTime(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// FormatTimestamp converts a Slack timestamp to local time, to the minute
+func FormatTimestamp(ts string) string {
+	sec, _, _ := strings.Cut(ts, ".")
+	unix, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return ts
+	}
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
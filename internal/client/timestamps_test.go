@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareTimestamps(t *testing.T) {
+	assert.Equal(t, -1, CompareTimestamps("999999999.000100", "1700000000.000100"))
+	assert.Equal(t, 1, CompareTimestamps("1700000000.000200", "1700000000.000100"))
+	assert.Equal(t, 0, CompareTimestamps("1700000000.000100", "1700000000.000100"))
+	assert.Equal(t, 1, CompareTimestamps("1700000000.000100", ""))
+}
+
+func TestChronological(t *testing.T) {
+	messages := []Message{{TS: "1700000000.000200"}, {TS: "999999999.000100"}, {TS: "1700000000.000100"}}
+	sorted := Chronological(messages)
+
+	assert.Equal(t, []string{"999999999.000100", "1700000000.000100", "1700000000.000200"},
+		[]string{sorted[0].TS, sorted[1].TS, sorted[2].TS}, "sorted numerically, not as strings")
+	assert.Equal(t, "1700000000.000200", messages[0].TS, "the input is left as it was")
+}
+
+func TestTimestampFromTime(t *testing.T) {
+	assert.Equal(t, "1700000000.000123", TimestampFromTime(time.Unix(1700000000, 123456)))
+	assert.Equal(t, "not-a-timestamp", FormatTimestamp("not-a-timestamp"))
+	assert.Equal(t, time.Unix(1700000000, 0).Format("2006-01-02 15:04"), FormatTimestamp("1700000000.000100"))
+}
+
+func TestSleepContext(t *testing.T) {
+	assert.True(t, SleepContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, SleepContext(ctx, time.Hour))
+}
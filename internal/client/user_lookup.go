@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveUser takes a user identifier (ID, @handle, display name or email) and returns the user ID.
+// If the input looks like a user ID (starts with U or W), it's returned as-is.
+// Otherwise, it's looked up via the Slack API.
+func (c *Client) ResolveUser(user string) (string, error) {
+	// Strip leading @ if present
+	user = strings.TrimPrefix(strings.TrimSpace(user), "@")
+
+	if user == "" {
+		return "", fmt.Errorf("user cannot be empty")
+	}
+
+	// If it looks like a user ID, return it as-is
+	if IsUserID(user) {
+		return user, nil
+	}
+
+	// Otherwise, look it up by name
+	return c.lookupUserByName(user)
+}
+
+// IsUserID returns true if the string looks like a Slack user ID.
+// User IDs start with U (regular user) or W (enterprise user), followed by
+// uppercase letters and digits including at least one digit.
+func IsUserID(s string) bool {
+	if len(s) < 2 || (s[0] != 'U' && s[0] != 'W') {
+		return false
+	}
+
+	hasDigit := false
+	for _, c := range s[1:] {
+		if c >= '0' && c <= '9' {
+			hasDigit = true
+		} else if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return hasDigit
+}
+
+// lookupUserByName searches for a user by handle, display name, real name or email and returns its ID.
+func (c *Client) lookupUserByName(name string) (string, error) {
+	users, err := c.ListAllUsers()
+	if err != nil {
+		return "", fmt.Errorf("failed to list users: %w", err)
+	}
+
+	// Prefer an exact handle match, then fall back to other names
+	for _, u := range users {
+		if strings.EqualFold(u.Name, name) {
+			return u.ID, nil
+		}
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Profile.DisplayName, name) ||
+			strings.EqualFold(u.RealName, name) ||
+			strings.EqualFold(u.Profile.Email, name) {
+			return u.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("user '%s' not found. Use 'slck users list' to see available users", name)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUserID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"U123ABC", true},
+		{"W02DF3BEUGN", true},
+		{"UNKNOWN", false}, // pure letters - treated as name
+		{"alice", false},
+		{"@alice", false},
+		{"u123abc", false}, // lowercase
+		{"C123456", false}, // channel ID
+		{"U", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsUserID(tt.input))
+		})
+	}
+}
+
+func TestResolveUser_Name(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users.list", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"members": []map[string]interface{}{
+				{"id": "U111111", "name": "alice", "real_name": "Alice Smith", "profile": map[string]interface{}{"display_name": "Ali", "email": "alice@example.com"}},
+				{"id": "U222222", "name": "bob", "real_name": "Bob Jones"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "test-token", nil)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"U999999", "U999999"},
+		{"@alice", "U111111"},
+		{"BOB", "U222222"},
+		{"Ali", "U111111"},
+		{"Alice Smith", "U111111"},
+		{"alice@example.com", "U111111"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := c.ResolveUser(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := c.ResolveUser("@carol")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slck users list")
+}
+
+func TestResolveUser_SearchesEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Five full pages of other users, then the one we want
+		page, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		resp := map[string]interface{}{"ok": true}
+		if page < 5 {
+			var members []map[string]interface{}
+			for i := 0; i < 200; i++ {
+				members = append(members, map[string]interface{}{"id": fmt.Sprintf("U%d", page*200+i), "name": fmt.Sprintf("user%d", page*200+i)})
+			}
+			resp["members"] = members
+			resp["response_metadata"] = map[string]string{"next_cursor": strconv.Itoa(page + 1)}
+		} else {
+			resp["members"] = []map[string]interface{}{{"id": "U999999", "name": "zoe"}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "test-token", nil)
+	id, err := c.ResolveUser("@zoe")
+	require.NoError(t, err)
+	assert.Equal(t, "U999999", id)
+}
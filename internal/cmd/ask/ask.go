@@ -0,0 +1,375 @@
+package ask
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// Exit codes reported for each outcome
+const (
+	exitApproved = 0
+	exitDenied   = 1
+	exitTimeout  = 2
+)
+
+// Outcomes of an approval request
+const (
+	outcomeApproved  = "approved"
+	outcomeDenied    = "denied"
+	outcomeTimeout   = "timeout"
+	outcomeCancelled = "cancelled"
+)
+
+var (
+	approveEmoji = []string{"white_check_mark", "heavy_check_mark"}
+	denyEmoji    = []string{"x", "no_entry"}
+
+	approveWords = []string{"approve", "approved", ":white_check_mark:", ":heavy_check_mark:", "✅"}
+	denyWords    = []string{"deny", "denied", ":x:", ":no_entry:", "❌"}
+)
+
+type askOptions struct {
+	approvers []string
+	timeout   time.Duration
+	interval  time.Duration
+}
+
+// decision is an approval or denial by one user
+type decision struct {
+	outcome string
+	user    string
+	comment string
+}
+
+// askResult is the JSON output of ask
+type askResult struct {
+	Channel  string `json:"channel"`
+	TS       string `json:"ts"`
+	Outcome  string `json:"outcome"`
+	User     string `json:"user,omitempty"`
+	UserName string `json:"user_name,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// exitError carries the exit code for a denied or timed out request
+type exitError struct {
+	code int
+	msg  string
+}
+
+func (e *exitError) Error() string { return e.msg }
+
+// ExitCode returns the process exit code for this outcome
+func (e *exitError) ExitCode() int { return e.code }
+
+// NewCmd creates the ask command
+func NewCmd() *cobra.Command {
+	opts := &askOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ask <channel> <question>",
+		Short: "Ask for approval and wait for the answer",
+		Long: `Post a question and block until an approver answers it, for gating
+scripts and deploy pipelines on a human OK.
+
+Approvers answer by reacting with :white_check_mark: or :x:, or by replying
+"approve" or "deny" in the thread. Anything after the word in a reply is kept
+as a comment. If both an approval and a denial are seen in the same check, the
+denial wins. Without --approvers, anyone but the asker may answer.
+
+The question is updated with the outcome once answered.
+
+Exit codes:
+  0  approved
+  1  denied (or an error occurred)
+  2  timed out
+
+Examples:
+  slck ask deploys "Deploy v1.2 to prod?" --approvers @alice,@bob --timeout 30m
+  slck ask deploys "Run the migration?" --approvers U01234ABCDE && ./migrate.sh`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Outcomes are reported through the exit code, not usage help
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runAsk(ctx, args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.approvers, "approvers", nil, "Users allowed to answer (IDs, @handles or emails)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Minute, "How long to wait for an answer")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 5*time.Second, "Time between checks for an answer")
+
+	return cmd
+}
+
+func runAsk(ctx context.Context, channel, question string, opts *askOptions, c *client.Client) error {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return fmt.Errorf("question cannot be empty")
+	}
+	if opts.timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	approvers := make(map[string]bool, len(opts.approvers))
+	mentions := make([]string, 0, len(opts.approvers))
+	for _, a := range opts.approvers {
+		userID, err := c.ResolveUser(a)
+		if err != nil {
+			return err
+		}
+		approvers[userID] = true
+		mentions = append(mentions, "<@"+userID+">")
+	}
+
+	// The asker cannot answer their own question
+	auth, err := c.AuthTest()
+	if err != nil {
+		return client.WrapError("identify current user", err)
+	}
+	self := auth.UserID
+
+	msg, err := c.SendMessage(channelID, formatQuestion(question, mentions), "", nil, false)
+	if err != nil {
+		return client.WrapError("post question", err)
+	}
+
+	g := &gate{
+		client:    c,
+		channel:   channelID,
+		ts:        msg.TS,
+		approvers: approvers,
+		self:      self,
+		oldest:    msg.TS,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	var d *decision
+	for d == nil {
+		d, err = g.check()
+		if wait, ok := client.IsRateLimited(err); ok {
+			if !client.SleepContext(ctx, wait) {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if d == nil && !client.SleepContext(ctx, opts.interval) {
+			break
+		}
+	}
+
+	if d == nil {
+		d = &decision{outcome: outcomeTimeout}
+		if ctx.Err() == context.Canceled {
+			d.outcome = outcomeCancelled
+		}
+	}
+
+	return g.finish(question, d)
+}
+
+// gate polls a posted question for an answer
+type gate struct {
+	client    *client.Client
+	channel   string
+	ts        string
+	approvers map[string]bool
+	self      string
+	oldest    string
+}
+
+// check looks for an answer in the question's reactions and thread replies
+func (g *gate) check() (*decision, error) {
+	msg, err := g.client.GetReactions(g.channel, g.ts)
+	if err != nil {
+		return nil, client.WrapError("get reactions", err)
+	}
+
+	var approval, denial *decision
+	for _, r := range msg.Reactions {
+		outcome := ""
+		switch {
+		case slices.Contains(approveEmoji, r.Name):
+			outcome = outcomeApproved
+		case slices.Contains(denyEmoji, r.Name):
+			outcome = outcomeDenied
+		default:
+			continue
+		}
+		for _, u := range r.Users {
+			if !g.allowed(u) {
+				continue
+			}
+			if outcome == outcomeDenied && denial == nil {
+				denial = &decision{outcome: outcome, user: u}
+			}
+			if outcome == outcomeApproved && approval == nil {
+				approval = &decision{outcome: outcome, user: u}
+			}
+		}
+	}
+
+	replies, err := g.client.GetThreadRepliesSince(g.channel, g.ts, g.oldest, 200)
+	if err != nil {
+		return nil, client.WrapError("get replies", err)
+	}
+	for _, m := range replies {
+		// Slack always returns the parent message
+		if m.TS == g.ts {
+			continue
+		}
+		g.oldest = m.TS
+		if !g.allowed(m.User) {
+			continue
+		}
+		outcome, comment := parseReply(m.Text)
+		if outcome == outcomeDenied && denial == nil {
+			denial = &decision{outcome: outcome, user: m.User, comment: comment}
+		}
+		if outcome == outcomeApproved && approval == nil {
+			approval = &decision{outcome: outcome, user: m.User, comment: comment}
+		}
+	}
+
+	if denial != nil {
+		return denial, nil
+	}
+	return approval, nil
+}
+
+// allowed reports whether a user may answer
+func (g *gate) allowed(userID string) bool {
+	if userID == "" || userID == g.self {
+		return false
+	}
+	if len(g.approvers) == 0 {
+		return true
+	}
+	return g.approvers[userID]
+}
+
+// finish updates the question with the outcome, prints it and returns the exit status
+func (g *gate) finish(question string, d *decision) error {
+	var name string
+	if d.user != "" {
+		name = client.NewUserResolver(g.client).Resolve(d.user)
+	}
+
+	if err := g.client.UpdateMessage(g.channel, g.ts, formatOutcome(question, d), nil, false); err != nil {
+		return client.WrapError("update question", err)
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(askResult{
+			Channel:  g.channel,
+			TS:       g.ts,
+			Outcome:  d.outcome,
+			User:     d.user,
+			UserName: name,
+			Comment:  d.comment,
+		}); err != nil {
+			return err
+		}
+	}
+
+	switch d.outcome {
+	case outcomeApproved:
+		if !output.IsJSON() {
+			output.Printf("Approved by %s\n", name)
+		}
+		return nil
+	case outcomeDenied:
+		return &exitError{code: exitDenied, msg: "denied by " + name}
+	case outcomeCancelled:
+		return &exitError{code: exitDenied, msg: "approval request cancelled"}
+	default:
+		return &exitError{code: exitTimeout, msg: "timed out waiting for approval"}
+	}
+}
+
+// parseReply returns the outcome a reply asks for, if any, and any comment after it
+func parseReply(text string) (outcome, comment string) {
+	text = strings.TrimSpace(text)
+	word, rest, _ := strings.Cut(text, " ")
+	word = strings.ToLower(strings.TrimRight(word, ".!,:;"))
+	if strings.HasPrefix(text, ":") {
+		// Keep the colons of an emoji code
+		word = strings.ToLower(strings.Fields(text)[0])
+	}
+
+	switch {
+	case slices.Contains(approveWords, word):
+		return outcomeApproved, strings.TrimSpace(rest)
+	case slices.Contains(denyWords, word):
+		return outcomeDenied, strings.TrimSpace(rest)
+	default:
+		return "", ""
+	}
+}
+
+// formatQuestion renders the posted question
+func formatQuestion(question string, mentions []string) string {
+	var b strings.Builder
+	b.WriteString(":raising_hand: *Approval needed:* " + question + "\n")
+	if len(mentions) > 0 {
+		b.WriteString("Approvers: " + strings.Join(mentions, ", ") + "\n")
+	}
+	b.WriteString("_React with :white_check_mark: to approve or :x: to deny, or reply \"approve\" or \"deny\" in the thread._")
+	return b.String()
+}
+
+// formatOutcome renders the question once answered
+func formatOutcome(question string, d *decision) string {
+	var line string
+	switch d.outcome {
+	case outcomeApproved:
+		line = ":white_check_mark: *Approved* by <@" + d.user + ">: " + question
+	case outcomeDenied:
+		line = ":x: *Denied* by <@" + d.user + ">: " + question
+	case outcomeCancelled:
+		line = ":no_entry_sign: *Cancelled:* " + question
+	default:
+		line = ":hourglass: *Timed out:* " + question
+	}
+	if d.comment != "" {
+		line += "\n> " + d.comment
+	}
+	return line
+}
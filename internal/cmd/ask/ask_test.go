@@ -0,0 +1,193 @@
+package ask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// askServer simulates Slack for an approval request. Reactions and replies
+// appear after the given number of checks.
+type askServer struct {
+	mu        sync.Mutex
+	checks    int
+	after     int
+	reactions []map[string]interface{}
+	replies   []map[string]interface{}
+	posted    map[string]interface{}
+	updated   map[string]interface{}
+}
+
+func (s *askServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"members": []map[string]interface{}{
+					{"id": "U001", "name": "alice"},
+					{"id": "U002", "name": "bob"},
+				},
+			})
+		case "/users.info":
+			id := r.URL.Query().Get("user")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": id, "name": map[string]string{"U001": "alice", "U002": "bob", "U003": "carol"}[id]},
+			})
+		case "/chat.postMessage":
+			_ = json.NewDecoder(r.Body).Decode(&s.posted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1700000000.000100"})
+		case "/chat.update":
+			_ = json.NewDecoder(r.Body).Decode(&s.updated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/reactions.get":
+			s.checks++
+			var reactions []map[string]interface{}
+			if s.checks > s.after {
+				reactions = s.reactions
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"message": map[string]interface{}{"ts": "1700000000.000100", "reactions": reactions},
+			})
+		case "/conversations.replies":
+			messages := []map[string]interface{}{{"ts": "1700000000.000100", "user": "UBOT", "text": "question"}}
+			if s.checks > s.after {
+				messages = append(messages, s.replies...)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": messages})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}
+}
+
+func runTestAsk(t *testing.T, s *askServer, opts *askOptions) (string, error) {
+	server := httptest.NewServer(s.handler(t))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	t.Cleanup(func() { output.Writer = os.Stdout })
+
+	if opts.interval == 0 {
+		opts.interval = 5 * time.Millisecond
+	}
+	if opts.timeout == 0 {
+		opts.timeout = 2 * time.Second
+	}
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runAsk(context.Background(), "C123", "Deploy v1.2 to prod?", opts, c)
+	return buf.String(), err
+}
+
+func exitCode(err error) int {
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) {
+		return coded.ExitCode()
+	}
+	return -1
+}
+
+func TestRunAsk_ApprovedByReaction(t *testing.T) {
+	s := &askServer{
+		after: 2,
+		reactions: []map[string]interface{}{
+			// Reactions by the asker and non-approvers are ignored
+			{"name": "x", "count": 2, "users": []string{"UBOT", "U003"}},
+			{"name": "white_check_mark", "count": 1, "users": []string{"U002"}},
+		},
+	}
+
+	out, err := runTestAsk(t, s, &askOptions{approvers: []string{"@alice", "@bob"}})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Approved by bob")
+	assert.Equal(t, 3, s.checks)
+
+	assert.Contains(t, s.posted["text"], "Approvers: <@U001>, <@U002>")
+	assert.Equal(t, "1700000000.000100", s.updated["ts"])
+	assert.Contains(t, s.updated["text"], "*Approved* by <@U002>")
+}
+
+func TestRunAsk_DeniedByReplyWithComment(t *testing.T) {
+	s := &askServer{
+		replies: []map[string]interface{}{
+			{"ts": "1700000001.000000", "user": "U001", "text": "Deny: error rate is still high"},
+		},
+	}
+
+	_, err := runTestAsk(t, s, &askOptions{approvers: []string{"U001"}})
+	require.Error(t, err)
+	assert.Equal(t, exitDenied, exitCode(err))
+	assert.Contains(t, err.Error(), "denied by alice")
+	assert.Contains(t, s.updated["text"], "> error rate is still high")
+}
+
+func TestRunAsk_DenialWins(t *testing.T) {
+	s := &askServer{
+		reactions: []map[string]interface{}{
+			{"name": "white_check_mark", "count": 1, "users": []string{"U001"}},
+		},
+		replies: []map[string]interface{}{
+			{"ts": "1700000001.000000", "user": "U002", "text": "deny"},
+		},
+	}
+
+	_, err := runTestAsk(t, s, &askOptions{})
+	require.Error(t, err)
+	assert.Equal(t, exitDenied, exitCode(err))
+}
+
+func TestRunAsk_Timeout(t *testing.T) {
+	s := &askServer{after: 1000}
+
+	_, err := runTestAsk(t, s, &askOptions{timeout: 30 * time.Millisecond})
+	require.Error(t, err)
+	assert.Equal(t, exitTimeout, exitCode(err))
+	assert.Contains(t, s.updated["text"], "*Timed out:*")
+}
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		text        string
+		wantOutcome string
+		wantComment string
+	}{
+		{"approve", outcomeApproved, ""},
+		{"Approved!", outcomeApproved, ""},
+		{":white_check_mark: ship it", outcomeApproved, "ship it"},
+		{"✅", outcomeApproved, ""},
+		{"deny - not during the freeze", outcomeDenied, "- not during the freeze"},
+		{"❌", outcomeDenied, ""},
+		{"I approve of this", "", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			outcome, comment := parseReply(tt.text)
+			assert.Equal(t, tt.wantOutcome, outcome)
+			assert.Equal(t, tt.wantComment, comment)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package channels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+func newBookmarksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bookmarks",
+		Aliases: []string{"bm"},
+		Short:   "Manage channel bookmarks",
+	}
+
+	cmd.AddCommand(newBookmarksListCmd())
+	cmd.AddCommand(newBookmarksAddCmd())
+	cmd.AddCommand(newBookmarksEditCmd())
+	cmd.AddCommand(newBookmarksRemoveCmd())
+	cmd.AddCommand(newBookmarksSyncCmd())
+
+	return cmd
+}
+
+// findBookmark looks up a bookmark by ID or, failing that, by exact title
+func findBookmark(bookmarks []client.Bookmark, ref string) (*client.Bookmark, error) {
+	for i := range bookmarks {
+		if bookmarks[i].ID == ref {
+			return &bookmarks[i], nil
+		}
+	}
+
+	var matches []*client.Bookmark
+	for i := range bookmarks {
+		if bookmarks[i].Title == ref {
+			matches = append(matches, &bookmarks[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("bookmark %q not found. Use 'slck channels bookmarks list' to see bookmarks", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple bookmarks titled %q; use the bookmark ID instead", ref)
+	}
+}
+
+// formatEmoji converts an emoji name to the :name: form Slack expects for bookmarks
+func formatEmoji(emoji string) string {
+	emoji = validate.Emoji(strings.TrimSpace(emoji))
+	if emoji == "" {
+		return ""
+	}
+	return ":" + emoji + ":"
+}
@@ -0,0 +1,77 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type bookmarksAddOptions struct {
+	title        string
+	link         string
+	emoji        string
+	bookmarkType string
+}
+
+func newBookmarksAddCmd() *cobra.Command {
+	opts := &bookmarksAddOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <channel>",
+		Short: "Add a bookmark to a channel",
+		Long: `Add a bookmark to a channel.
+
+Examples:
+  slck channels bookmarks add deploys --title Runbook --link https://wiki.example.com/runbook
+  slck channels bookmarks add deploys --title Dashboard --link https://grafana.example.com --emoji chart_with_upwards_trend`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksAdd(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.title, "title", "", "Bookmark title (required)")
+	cmd.Flags().StringVar(&opts.link, "link", "", "Bookmark URL (required)")
+	cmd.Flags().StringVar(&opts.emoji, "emoji", "", "Emoji shown next to the bookmark")
+	cmd.Flags().StringVar(&opts.bookmarkType, "type", "link", "Bookmark type")
+
+	return cmd
+}
+
+func runBookmarksAdd(channel string, opts *bookmarksAddOptions, c *client.Client) error {
+	if opts.title == "" {
+		return fmt.Errorf("--title is required")
+	}
+	if opts.link == "" {
+		return fmt.Errorf("--link is required")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	bookmark, err := c.AddBookmark(channelID, opts.title, opts.bookmarkType, opts.link, formatEmoji(opts.emoji))
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("add bookmark %q", opts.title), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(bookmark)
+	}
+
+	output.Printf("Added bookmark: %s (id: %s)\n", bookmark.Title, bookmark.ID)
+	return nil
+}
@@ -0,0 +1,83 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type bookmarksEditOptions struct {
+	title string
+	link  string
+	emoji string
+}
+
+func newBookmarksEditCmd() *cobra.Command {
+	opts := &bookmarksEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit <channel> <bookmark-id|title>",
+		Short: "Edit a channel bookmark",
+		Long: `Edit a channel bookmark, identified by its ID or title.
+
+Only the fields passed as flags are changed.
+
+Examples:
+  slck channels bookmarks edit deploys Runbook --link https://wiki.example.com/runbook-v2
+  slck channels bookmarks edit deploys Bk01234ABCDE --title "On-call runbook" --emoji book`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksEdit(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.title, "title", "", "New title")
+	cmd.Flags().StringVar(&opts.link, "link", "", "New URL")
+	cmd.Flags().StringVar(&opts.emoji, "emoji", "", "New emoji")
+
+	return cmd
+}
+
+func runBookmarksEdit(channel, ref string, opts *bookmarksEditOptions, c *client.Client) error {
+	if opts.title == "" && opts.link == "" && opts.emoji == "" {
+		return fmt.Errorf("nothing to change: provide --title, --link, or --emoji")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := c.ListBookmarks(channelID)
+	if err != nil {
+		return client.WrapError("list bookmarks", err)
+	}
+	existing, err := findBookmark(bookmarks, ref)
+	if err != nil {
+		return err
+	}
+
+	bookmark, err := c.EditBookmark(channelID, existing.ID, opts.title, opts.link, formatEmoji(opts.emoji))
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("edit bookmark %q", existing.Title), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(bookmark)
+	}
+
+	output.Printf("Updated bookmark: %s\n", existing.Title)
+	return nil
+}
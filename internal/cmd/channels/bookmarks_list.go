@@ -0,0 +1,62 @@
+package channels
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type bookmarksListOptions struct{}
+
+func newBookmarksListCmd() *cobra.Command {
+	opts := &bookmarksListOptions{}
+
+	return &cobra.Command{
+		Use:   "list <channel>",
+		Short: "List a channel's bookmarks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksList(args[0], opts, nil)
+		},
+	}
+}
+
+func runBookmarksList(channel string, opts *bookmarksListOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := c.ListBookmarks(channelID)
+	if err != nil {
+		return client.WrapError("list bookmarks", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(bookmarks)
+	}
+
+	if len(bookmarks) == 0 {
+		output.Println("No bookmarks found")
+		return nil
+	}
+
+	headers := []string{"ID", "EMOJI", "TITLE", "TYPE", "LINK"}
+	rows := make([][]string, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		rows = append(rows, []string{b.ID, b.Emoji, b.Title, b.Type, b.Link})
+	}
+	output.Table(headers, rows)
+
+	return nil
+}
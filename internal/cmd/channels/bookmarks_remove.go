@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type bookmarksRemoveOptions struct{}
+
+func newBookmarksRemoveCmd() *cobra.Command {
+	opts := &bookmarksRemoveOptions{}
+
+	return &cobra.Command{
+		Use:   "remove <channel> <bookmark-id|title>",
+		Short: "Remove a channel bookmark",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksRemove(args[0], args[1], opts, nil)
+		},
+	}
+}
+
+func runBookmarksRemove(channel, ref string, opts *bookmarksRemoveOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := c.ListBookmarks(channelID)
+	if err != nil {
+		return client.WrapError("list bookmarks", err)
+	}
+	existing, err := findBookmark(bookmarks, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RemoveBookmark(channelID, existing.ID); err != nil {
+		return client.WrapError(fmt.Sprintf("remove bookmark %q", existing.Title), err)
+	}
+
+	output.Printf("Removed bookmark: %s\n", existing.Title)
+	return nil
+}
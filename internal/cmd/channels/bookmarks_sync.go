@@ -0,0 +1,284 @@
+package channels
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type bookmarksSyncOptions struct {
+	fromFile string
+	dryRun   bool
+	force    bool
+	stdin    io.Reader // For testing
+}
+
+// bookmarkSpec is a bookmark as listed in a --from-file YAML file
+type bookmarkSpec struct {
+	Title string `yaml:"title" json:"title"`
+	Link  string `yaml:"link" json:"link"`
+	Emoji string `yaml:"emoji" json:"emoji,omitempty"`
+	Type  string `yaml:"type" json:"type,omitempty"`
+}
+
+// bookmarkChange describes an update to an existing bookmark
+type bookmarkChange struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Link     string `json:"link,omitempty"`
+	Emoji    string `json:"emoji,omitempty"`
+	OldLink  string `json:"old_link,omitempty"`
+	OldEmoji string `json:"old_emoji,omitempty"`
+}
+
+// bookmarkPlan lists the changes needed to make a channel match a bookmarks file
+type bookmarkPlan struct {
+	Add    []bookmarkSpec    `json:"add"`
+	Update []bookmarkChange  `json:"update"`
+	Remove []client.Bookmark `json:"remove"`
+}
+
+func (p *bookmarkPlan) size() int {
+	return len(p.Add) + len(p.Update) + len(p.Remove)
+}
+
+func newBookmarksSyncCmd() *cobra.Command {
+	opts := &bookmarksSyncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync <channel> --from-file <file>",
+		Short: "Sync a channel's bookmarks from a YAML file",
+		Long: `Make a channel's bookmark bar match a YAML list of bookmarks.
+
+Bookmarks are matched by title. Bookmarks in the file but not the channel are
+added, bookmarks whose link or emoji differ are updated, and bookmarks in the
+channel but not the file are removed. An emoji left out of the file is not
+changed.
+
+The planned changes are shown before anything is applied, and you are asked to
+confirm unless --force is given. If nothing answers the prompt, as in a script
+or CI job, nothing is changed. Use --dry-run to only show the changes.
+
+File format:
+  - title: Runbook
+    link: https://wiki.example.com/runbook
+    emoji: book
+  - title: Dashboard
+    link: https://grafana.example.com/d/deploys
+
+Examples:
+  slck channels bookmarks sync deploys --from-file bookmarks.yaml --dry-run
+  slck channels bookmarks sync deploys --from-file bookmarks.yaml --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBookmarksSync(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.fromFile, "from-file", "", "YAML file listing the desired bookmarks (required)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show changes without applying them")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runBookmarksSync(channel string, opts *bookmarksSyncOptions, c *client.Client) error {
+	if opts.fromFile == "" {
+		return fmt.Errorf("--from-file is required")
+	}
+	desired, err := loadBookmarkSpecs(opts.fromFile)
+	if err != nil {
+		return err
+	}
+	if output.IsJSON() && !opts.dryRun && !opts.force {
+		return fmt.Errorf("use --dry-run or --force with JSON output")
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.ListBookmarks(channelID)
+	if err != nil {
+		return client.WrapError("list bookmarks", err)
+	}
+
+	plan := planBookmarkSync(existing, desired)
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(plan); err != nil {
+			return err
+		}
+	} else {
+		printBookmarkPlan(channel, plan)
+	}
+
+	if plan.size() == 0 || opts.dryRun {
+		return nil
+	}
+
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("Apply %d change(s)? [y/N]: ", plan.size())
+
+		// No answer (such as stdin closed in CI) never goes ahead
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println()
+			return fmt.Errorf("no answer to the confirmation prompt; use --force to sync without confirming")
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	for _, b := range plan.Add {
+		bookmarkType := b.Type
+		if bookmarkType == "" {
+			bookmarkType = "link"
+		}
+		if _, err := c.AddBookmark(channelID, b.Title, bookmarkType, b.Link, formatEmoji(b.Emoji)); err != nil {
+			return client.WrapError(fmt.Sprintf("add bookmark %q", b.Title), err)
+		}
+	}
+	for _, u := range plan.Update {
+		if _, err := c.EditBookmark(channelID, u.ID, "", u.Link, formatEmoji(u.Emoji)); err != nil {
+			return client.WrapError(fmt.Sprintf("edit bookmark %q", u.Title), err)
+		}
+	}
+	for _, b := range plan.Remove {
+		if err := c.RemoveBookmark(channelID, b.ID); err != nil {
+			return client.WrapError(fmt.Sprintf("remove bookmark %q", b.Title), err)
+		}
+	}
+
+	if !output.IsJSON() {
+		output.Printf("Bookmarks synced: %d added, %d updated, %d removed\n", len(plan.Add), len(plan.Update), len(plan.Remove))
+	}
+	return nil
+}
+
+// loadBookmarkSpecs reads and validates a YAML list of bookmarks
+func loadBookmarkSpecs(path string) ([]bookmarkSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading bookmarks file: %w", err)
+	}
+
+	var specs []bookmarkSpec
+	if err := yaml.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("parsing bookmarks file: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, spec := range specs {
+		if spec.Title == "" || spec.Link == "" {
+			return nil, fmt.Errorf("bookmark %d: title and link are required", i+1)
+		}
+		if seen[spec.Title] {
+			return nil, fmt.Errorf("bookmark %d: duplicate title %q", i+1, spec.Title)
+		}
+		seen[spec.Title] = true
+	}
+	return specs, nil
+}
+
+// planBookmarkSync compares existing bookmarks with the desired list by title
+func planBookmarkSync(existing []client.Bookmark, desired []bookmarkSpec) *bookmarkPlan {
+	plan := &bookmarkPlan{
+		Add:    []bookmarkSpec{},
+		Update: []bookmarkChange{},
+		Remove: []client.Bookmark{},
+	}
+
+	byTitle := make(map[string]client.Bookmark)
+	for _, b := range existing {
+		if _, dup := byTitle[b.Title]; dup {
+			// Extra bookmarks with the same title are always removed
+			plan.Remove = append(plan.Remove, b)
+			continue
+		}
+		byTitle[b.Title] = b
+	}
+
+	wanted := make(map[string]bool)
+	for _, spec := range desired {
+		wanted[spec.Title] = true
+
+		current, ok := byTitle[spec.Title]
+		if !ok {
+			plan.Add = append(plan.Add, spec)
+			continue
+		}
+
+		change := bookmarkChange{ID: current.ID, Title: current.Title}
+		if spec.Link != current.Link {
+			change.Link = spec.Link
+			change.OldLink = current.Link
+		}
+		if spec.Emoji != "" && validate.Emoji(spec.Emoji) != validate.Emoji(current.Emoji) {
+			change.Emoji = spec.Emoji
+			change.OldEmoji = current.Emoji
+		}
+		if change.Link != "" || change.Emoji != "" {
+			plan.Update = append(plan.Update, change)
+		}
+	}
+
+	for _, b := range existing {
+		if !wanted[b.Title] && byTitle[b.Title].ID == b.ID {
+			plan.Remove = append(plan.Remove, b)
+		}
+	}
+
+	return plan
+}
+
+// printBookmarkPlan shows the planned changes as a diff
+func printBookmarkPlan(channel string, plan *bookmarkPlan) {
+	if plan.size() == 0 {
+		output.Printf("Bookmarks for %s are already up to date\n", channel)
+		return
+	}
+
+	output.Printf("Bookmark changes for %s:\n", channel)
+	for _, b := range plan.Add {
+		output.Printf("  + %s  %s\n", b.Title, b.Link)
+	}
+	for _, u := range plan.Update {
+		if u.Link != "" {
+			output.Printf("  ~ %s  link: %s -> %s\n", u.Title, u.OldLink, u.Link)
+		}
+		if u.Emoji != "" {
+			output.Printf("  ~ %s  emoji: %s -> %s\n", u.Title, u.OldEmoji, formatEmoji(u.Emoji))
+		}
+	}
+	for _, b := range plan.Remove {
+		output.Printf("  - %s  %s\n", b.Title, b.Link)
+	}
+}
@@ -0,0 +1,232 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// bookmarksServer serves bookmarks.list with the given bookmarks and records
+// the paths of every other call made
+func bookmarksServer(t *testing.T, bookmarks []map[string]interface{}, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bookmarks.list" {
+			assert.Equal(t, "C123", r.URL.Query().Get("channel_id"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":        true,
+				"bookmarks": bookmarks,
+			})
+			return
+		}
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		*calls = append(*calls, r.URL.Path+" "+bodyString(body))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":       true,
+			"bookmark": map[string]interface{}{"id": "Bk999", "title": body["title"]},
+		})
+	}))
+}
+
+func bodyString(body map[string]interface{}) string {
+	var parts []string
+	for _, key := range []string{"bookmark_id", "title", "link", "emoji"} {
+		if v, ok := body[key]; ok {
+			parts = append(parts, key+"="+v.(string))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func existingBookmarks() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": "Bk001", "channel_id": "C123", "title": "Runbook", "link": "https://wiki.example.com/runbook", "emoji": ":book:", "type": "link"},
+		{"id": "Bk002", "channel_id": "C123", "title": "Old docs", "link": "https://old.example.com", "type": "link"},
+	}
+}
+
+func TestRunBookmarksList_Table(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runBookmarksList("C123", &bookmarksListOptions{}, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Runbook")
+	assert.Contains(t, buf.String(), "https://old.example.com")
+}
+
+func TestRunBookmarksAdd_Success(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, nil, &calls)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksAddOptions{title: "Dashboard", link: "https://grafana.example.com", emoji: "chart", bookmarkType: "link"}
+
+	err := runBookmarksAdd("C123", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bookmarks.add title=Dashboard link=https://grafana.example.com emoji=:chart:"}, calls)
+}
+
+func TestRunBookmarksAdd_MissingLink(t *testing.T) {
+	err := runBookmarksAdd("C123", &bookmarksAddOptions{title: "Dashboard", bookmarkType: "link"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--link")
+}
+
+func TestRunBookmarksEdit_ByTitle(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksEditOptions{link: "https://wiki.example.com/runbook-v2"}
+
+	err := runBookmarksEdit("C123", "Runbook", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/bookmarks.edit bookmark_id=Bk001 link=https://wiki.example.com/runbook-v2"}, calls)
+}
+
+func TestRunBookmarksRemove_NotFound(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runBookmarksRemove("C123", "Missing", &bookmarksRemoveOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Empty(t, calls)
+}
+
+func writeBookmarksFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "bookmarks.yaml")
+	content := `- title: Runbook
+  link: https://wiki.example.com/runbook-v2
+  emoji: book
+- title: Dashboard
+  link: https://grafana.example.com
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRunBookmarksSync_Apply(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksSyncOptions{fromFile: writeBookmarksFile(t), stdin: strings.NewReader("y\n")}
+
+	err := runBookmarksSync("C123", opts, c)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "+ Dashboard")
+	assert.Contains(t, buf.String(), "~ Runbook  link: https://wiki.example.com/runbook -> https://wiki.example.com/runbook-v2")
+	assert.Contains(t, buf.String(), "- Old docs")
+	assert.Equal(t, []string{
+		"/bookmarks.add title=Dashboard link=https://grafana.example.com",
+		"/bookmarks.edit bookmark_id=Bk001 link=https://wiki.example.com/runbook-v2",
+		"/bookmarks.remove bookmark_id=Bk002",
+	}, calls)
+}
+
+func TestRunBookmarksSync_DryRun(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksSyncOptions{fromFile: writeBookmarksFile(t), dryRun: true}
+
+	err := runBookmarksSync("C123", opts, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "+ Dashboard")
+	assert.Empty(t, calls)
+}
+
+func TestRunBookmarksSync_Cancelled(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksSyncOptions{fromFile: writeBookmarksFile(t), stdin: strings.NewReader("n\n")}
+
+	err := runBookmarksSync("C123", opts, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Cancelled")
+	assert.Empty(t, calls)
+}
+
+func TestRunBookmarksSync_NoAnswerChangesNothing(t *testing.T) {
+	var calls []string
+	server := bookmarksServer(t, existingBookmarks(), &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &bookmarksSyncOptions{fromFile: writeBookmarksFile(t), stdin: strings.NewReader("")}
+
+	err := runBookmarksSync("C123", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	assert.Empty(t, calls, "closed stdin is not a yes")
+}
+
+func TestPlanBookmarkSync_UpToDate(t *testing.T) {
+	existing := []client.Bookmark{
+		{ID: "Bk001", Title: "Runbook", Link: "https://wiki.example.com/runbook", Emoji: ":book:"},
+	}
+	desired := []bookmarkSpec{
+		{Title: "Runbook", Link: "https://wiki.example.com/runbook", Emoji: "book"},
+	}
+
+	plan := planBookmarkSync(existing, desired)
+	assert.Equal(t, 0, plan.size())
+}
+
+func TestLoadBookmarkSpecs_DuplicateTitle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookmarks.yaml")
+	content := "- title: A\n  link: https://a.example.com\n- title: A\n  link: https://b.example.com\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	_, err := loadBookmarkSpecs(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate title")
+}
@@ -20,6 +20,7 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newSetTopicCmd())
 	cmd.AddCommand(newSetPurposeCmd())
 	cmd.AddCommand(newInviteCmd())
+	cmd.AddCommand(newBookmarksCmd())
 
 	return cmd
 }
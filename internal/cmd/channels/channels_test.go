@@ -1,9 +1,12 @@
 package channels
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
 func TestRunList_Success(t *testing.T) {
@@ -393,3 +397,104 @@ func TestRunUnarchive_NotInChannel(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not_in_channel")
 }
+
+func TestRunList_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"channels": []map[string]interface{}{
+				{"id": "C001", "name": "general", "is_member": true, "is_general": true, "creator": "U001", "created": 1600000000},
+				{"id": "C002", "name": "partners", "is_ext_shared": true, "creator": "U002", "created": 1700000000},
+				{"id": "C003", "name": "deploys", "is_member": true, "creator": "U002", "created": 1710000000},
+			},
+		})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want []string
+	}{
+		{"member", listOptions{member: true}, []string{"C001", "C003"}},
+		{"not member", listOptions{notMember: true}, []string{"C002"}},
+		{"shared", listOptions{shared: true}, []string{"C002"}},
+		{"creator", listOptions{creator: "U002"}, []string{"C002", "C003"}},
+		{"created after", listOptions{createdAfter: "2023-12-01"}, []string{"C003"}},
+		{"created before", listOptions{createdBefore: "2023-12-01"}, []string{"C001", "C002"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			output.Writer = &buf
+			output.OutputFormat = output.FormatJSON
+			defer func() {
+				output.Writer = os.Stdout
+				output.OutputFormat = output.FormatText
+			}()
+
+			c := client.NewWithConfig(server.URL, "test-token", nil)
+			opts := tt.opts
+			opts.limit = 100
+			require.NoError(t, runList(&opts, c))
+
+			var channels []client.Channel
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &channels))
+			ids := make([]string, 0, len(channels))
+			for _, ch := range channels {
+				ids = append(ids, ch.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRunList_FiltersSearchEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		if r.URL.Query().Get("cursor") == "" {
+			// A first page of channels fills the default --limit
+			var channels []map[string]interface{}
+			for i := 0; i < 100; i++ {
+				channels = append(channels, map[string]interface{}{"id": fmt.Sprintf("C%03d", i), "name": fmt.Sprintf("channel%d", i)})
+			}
+			resp["channels"] = channels
+			resp["response_metadata"] = map[string]string{"next_cursor": "page2"}
+		} else {
+			resp["channels"] = []map[string]interface{}{
+				{"id": "CS1", "name": "partners", "is_ext_shared": true},
+				{"id": "CS2", "name": "vendors", "is_ext_shared": true},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runList(&listOptions{limit: 100, shared: true}, c))
+	var channels []client.Channel
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &channels))
+	require.Len(t, channels, 2, "shared channels on the second page are found")
+
+	// --limit applies to the matches
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{limit: 1, shared: true}, c))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &channels))
+	require.Len(t, channels, 1)
+	assert.Equal(t, "CS1", channels[0].ID)
+}
+
+func TestRunList_InvalidCreatedAfter(t *testing.T) {
+	err := runList(&listOptions{limit: 100, createdAfter: "not a time"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--created-after")
+}
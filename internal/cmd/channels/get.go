@@ -1,6 +1,8 @@
 package channels
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
@@ -50,7 +52,16 @@ func runGet(channel string, opts *getOptions, c *client.Client) error {
 	output.KeyValue("Name", ch.Name)
 	output.KeyValue("Private", ch.IsPrivate)
 	output.KeyValue("Archived", ch.IsArchived)
+	output.KeyValue("General", ch.IsGeneral)
+	output.KeyValue("Shared", sharing(*ch))
+	output.KeyValue("Member", ch.IsMember)
 	output.KeyValue("Members", ch.NumMembers)
+	if ch.Created != 0 {
+		output.KeyValue("Created", time.Unix(ch.Created, 0).Format("2006-01-02 15:04"))
+	}
+	if ch.Creator != "" {
+		output.KeyValue("Creator", client.NewUserResolver(c).Resolve(ch.Creator))
+	}
 	if ch.Topic.Value != "" {
 		output.KeyValue("Topic", ch.Topic.Value)
 	}
@@ -60,3 +71,18 @@ func runGet(channel string, opts *getOptions, c *client.Client) error {
 
 	return nil
 }
+
+// sharing describes whether a channel is shared outside the workspace
+func sharing(ch client.Channel) string {
+	switch {
+	case ch.IsExtShared:
+		return "external organizations"
+	case ch.IsPendingExtShared:
+		return "pending external invitation"
+	case ch.IsOrgShared:
+		return "workspaces in this organization"
+	case ch.IsShared:
+		return "yes"
+	}
+	return "no"
+}
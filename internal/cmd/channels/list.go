@@ -2,17 +2,25 @@ package channels
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
 )
 
 type listOptions struct {
 	types           string
 	excludeArchived bool
 	limit           int
+	member          bool
+	notMember       bool
+	shared          bool
+	creator         string
+	createdAfter    string
+	createdBefore   string
 }
 
 func newListCmd() *cobra.Command {
@@ -21,6 +29,17 @@ func newListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all channels",
+		Long: `List all channels.
+
+Filters can be combined; a channel must match all of them. With filters,
+every channel is searched and --limit caps the number of matches.
+--created-after and --created-before accept:
+` + timeparse.Syntax + `
+
+Examples:
+  slck channels list --member
+  slck channels list --shared -o json
+  slck channels list --creator alice --created-after 2024-01-01`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(opts, nil)
 		},
@@ -29,24 +48,102 @@ func newListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.types, "types", "", "Channel types (public_channel,private_channel,mpim,im)")
 	cmd.Flags().BoolVar(&opts.excludeArchived, "exclude-archived", true, "Exclude archived channels")
 	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum channels to return")
+	cmd.Flags().BoolVar(&opts.member, "member", false, "Only channels the token's user is a member of")
+	cmd.Flags().BoolVar(&opts.notMember, "not-member", false, "Only channels the token's user is not a member of")
+	cmd.Flags().BoolVar(&opts.shared, "shared", false, "Only channels shared with other workspaces or organizations")
+	cmd.Flags().StringVar(&opts.creator, "creator", "", "Only channels created by this user (ID, @name or email)")
+	cmd.Flags().StringVar(&opts.createdAfter, "created-after", "", "Only channels created after this time")
+	cmd.Flags().StringVar(&opts.createdBefore, "created-before", "", "Only channels created before this time")
 
 	return cmd
 }
 
+// channelFilter holds the resolved --member, --shared, --creator and
+// --created-* filters
+type channelFilter struct {
+	member, notMember, shared bool
+	creator                   string
+	after, before             time.Time
+}
+
+// active reports whether any of the filters are set
+func (f *channelFilter) active() bool {
+	return f.member || f.notMember || f.shared || f.creator != "" || !f.after.IsZero() || !f.before.IsZero()
+}
+
+func (f *channelFilter) matches(ch client.Channel) bool {
+	created := time.Unix(ch.Created, 0)
+	switch {
+	case f.member && !ch.IsMember:
+		return false
+	case f.notMember && ch.IsMember:
+		return false
+	case f.shared && !(ch.IsShared || ch.IsExtShared || ch.IsOrgShared):
+		return false
+	case f.creator != "" && ch.Creator != f.creator:
+		return false
+	case !f.after.IsZero() && !created.After(f.after):
+		return false
+	case !f.before.IsZero() && !created.Before(f.before):
+		return false
+	}
+	return true
+}
+
 func runList(opts *listOptions, c *client.Client) error {
+	if opts.member && opts.notMember {
+		return fmt.Errorf("only one of --member or --not-member can be specified")
+	}
+
+	filter := &channelFilter{member: opts.member, notMember: opts.notMember, shared: opts.shared}
+	now := time.Now()
+	var err error
+	if opts.createdAfter != "" {
+		if filter.after, err = timeparse.Parse(opts.createdAfter, now); err != nil {
+			return fmt.Errorf("--created-after: %w", err)
+		}
+	}
+	if opts.createdBefore != "" {
+		if filter.before, err = timeparse.Parse(opts.createdBefore, now); err != nil {
+			return fmt.Errorf("--created-before: %w", err)
+		}
+	}
+
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
 		}
 	}
 
-	channels, err := c.ListChannels(opts.types, opts.excludeArchived, opts.limit)
+	if opts.creator != "" {
+		if filter.creator, err = c.ResolveUser(opts.creator); err != nil {
+			return err
+		}
+	}
+
+	// Filters look at every channel, so --limit caps the matches rather than
+	// the channels searched
+	var all []client.Channel
+	if filter.active() {
+		all, err = c.ListAllChannels(opts.types, opts.excludeArchived)
+	} else {
+		all, err = c.ListChannels(opts.types, opts.excludeArchived, opts.limit)
+	}
 	if err != nil {
 		return err
 	}
 
+	channels := make([]client.Channel, 0, len(all))
+	for _, ch := range all {
+		if filter.matches(ch) {
+			channels = append(channels, ch)
+		}
+	}
+	if len(channels) > opts.limit {
+		channels = channels[:opts.limit]
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(channels)
 	}
@@ -56,14 +153,21 @@ func runList(opts *listOptions, c *client.Client) error {
 		return nil
 	}
 
-	headers := []string{"ID", "NAME", "MEMBERS"}
+	headers := []string{"ID", "NAME", "MEMBERS", "CREATED"}
 	rows := make([][]string, 0, len(channels))
 	for _, ch := range channels {
 		members := fmt.Sprintf("%d", ch.NumMembers)
 		if ch.IsPrivate {
 			members += " (private)"
 		}
-		rows = append(rows, []string{ch.ID, ch.Name, members})
+		if ch.IsExtShared {
+			members += " (shared)"
+		}
+		created := ""
+		if ch.Created != 0 {
+			created = time.Unix(ch.Created, 0).Format("2006-01-02")
+		}
+		rows = append(rows, []string{ch.ID, ch.Name, members, created})
 	}
 	output.Table(headers, rows)
 
@@ -0,0 +1,506 @@
+// Package export implements the export command, which archives channels in
+// the directory layout of Slack's own workspace export.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+)
+
+const (
+	// historyPageSize is how many messages are requested per history call
+	historyPageSize = 200
+
+	// maxThreadReplies bounds the replies fetched for a single thread
+	maxThreadReplies = 100000
+
+	// maxUsers bounds the members written to users.json
+	maxUsers = 100000
+
+	// uploadsDir holds downloaded files, as in Slack's own exports
+	uploadsDir = "__uploads"
+)
+
+type exportOptions struct {
+	since string
+	until string
+	out   string
+	files bool
+}
+
+// channelSummary reports what was exported for one channel in JSON mode
+type channelSummary struct {
+	Channel  string `json:"channel"`
+	Name     string `json:"name"`
+	Dir      string `json:"dir"`
+	Messages int    `json:"messages"`
+	Replies  int    `json:"replies"`
+	Files    int    `json:"files"`
+	Skipped  bool   `json:"skipped,omitempty"`
+}
+
+// NewCmd creates the export command
+func NewCmd() *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export <channel>...",
+		Short: "Export channels in Slack's export format",
+		Long: `Export channel history, including all thread replies, to a directory laid
+out like Slack's own workspace export:
+
+  channels.json          Public channels (groups.json, dms.json and mpims.json
+                         hold private channels, DMs and group DMs)
+  users.json             Workspace members
+  <channel>/YYYY-MM-DD.json
+                         Each day's messages and thread replies (days are UTC)
+  __uploads/<file-id>/   Attached files, with --files
+
+Progress is saved after every page of history, so an interrupted export picks
+up where it left off when run again with the same arguments. A relative
+--since or --until, such as "30 days ago", keeps the time it meant on the
+first run. Rate limited requests are retried after the wait Slack asks for.
+
+--since and --until accept Slack timestamps or:
+` + timeparse.Syntax + `
+
+Examples:
+  slck export project-x --out ./project-x-archive
+  slck export project-x project-x-eng --since 2024-01-01 --files
+  slck export alerts --since "30 days ago" -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runExport(ctx, args, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only export messages after this time")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only export messages before this time")
+	cmd.Flags().StringVar(&opts.out, "out", "slack-export", "Directory to write the export to")
+	cmd.Flags().BoolVar(&opts.files, "files", false, "Download attached files (needs the files:read scope)")
+
+	return cmd
+}
+
+func runExport(ctx context.Context, channels []string, opts *exportOptions, c *client.Client) error {
+	oldest, err := timeparse.Bound(opts.since, "--since")
+	if err != nil {
+		return err
+	}
+	latest, err := timeparse.Bound(opts.until, "--until")
+	if err != nil {
+		return err
+	}
+	if opts.out == "" {
+		return fmt.Errorf("--out cannot be empty")
+	}
+
+	st, err := loadState(opts.out)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	e := &exporter{
+		ctx:    ctx,
+		client: c,
+		out:    opts.out,
+		files:  opts.files,
+		state:  st,
+		since:  opts.since,
+		until:  opts.until,
+		oldest: oldest,
+		latest: latest,
+	}
+
+	infos := make([]client.Channel, 0, len(channels))
+	for _, channel := range channels {
+		channelID, err := c.ResolveChannel(channel)
+		if err != nil {
+			return err
+		}
+		var info *client.Channel
+		if err := e.call(func() (err error) {
+			info, err = c.GetChannelInfo(channelID)
+			return err
+		}); err != nil {
+			return client.WrapError(fmt.Sprintf("get channel %s", channel), err)
+		}
+		infos = append(infos, *info)
+	}
+
+	var users []client.User
+	if err := e.call(func() (err error) {
+		users, err = c.ListUsers(maxUsers)
+		return err
+	}); err != nil {
+		return client.WrapError("list users", err)
+	}
+	if err := writeJSON(filepath.Join(opts.out, "users.json"), users); err != nil {
+		return err
+	}
+	if err := writeChannelIndexes(opts.out, infos); err != nil {
+		return err
+	}
+
+	summaries := make([]channelSummary, 0, len(infos))
+	for _, ch := range infos {
+		summary, err := e.exportChannel(ch)
+		if errors.Is(err, context.Canceled) {
+			return fmt.Errorf("export interrupted; run the same command again to resume")
+		}
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, summary)
+
+		if !output.IsJSON() {
+			if summary.Skipped {
+				output.Printf("#%s: already exported, skipped\n", summary.Name)
+				continue
+			}
+			output.Printf("#%s: %d messages, %d thread replies", summary.Name, summary.Messages, summary.Replies)
+			if opts.files {
+				output.Printf(", %d files", summary.Files)
+			}
+			output.Println()
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(summaries)
+	}
+	output.Printf("Export written to %s\n", opts.out)
+	return nil
+}
+
+// exporter writes channel history into an export directory
+type exporter struct {
+	ctx    context.Context
+	client *client.Client
+	out    string
+	files  bool
+	state  *state
+	since  string
+	until  string
+	oldest string
+	latest string
+}
+
+// exportChannel writes a channel's history page by page, newest first,
+// saving progress after each page
+func (e *exporter) exportChannel(ch client.Channel) (channelSummary, error) {
+	cs := e.state.channel(ch.ID, ch.Name, e.since, e.until, e.oldest, e.latest)
+	dir := channelDir(ch)
+	summary := channelSummary{Channel: ch.ID, Name: cs.Name, Dir: dir}
+	if cs.Done {
+		summary.Messages, summary.Replies, summary.Files = cs.Messages, cs.Replies, cs.Files
+		summary.Skipped = true
+		return summary, nil
+	}
+
+	latest := cs.Latest
+	if cs.Cursor != "" {
+		latest = cs.Cursor
+	}
+
+	for {
+		if err := e.ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		var page []client.Message
+		if err := e.call(func() (err error) {
+			page, err = e.client.GetChannelHistory(ch.ID, historyPageSize, cs.Oldest, latest)
+			return err
+		}); err != nil {
+			return summary, client.WrapError(fmt.Sprintf("read history of #%s", cs.Name), err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		msgs := append([]client.Message(nil), page...)
+		for _, m := range page {
+			if m.ReplyCount == 0 || (m.ThreadTS != "" && m.ThreadTS != m.TS) {
+				continue
+			}
+			var thread []client.Message
+			if err := e.call(func() (err error) {
+				thread, err = e.client.GetThreadReplies(ch.ID, m.TS, maxThreadReplies)
+				return err
+			}); err != nil {
+				return summary, client.WrapError(fmt.Sprintf("read thread %s in #%s", m.TS, cs.Name), err)
+			}
+			for _, r := range thread {
+				if r.TS != m.TS {
+					msgs = append(msgs, r)
+					cs.Replies++
+				}
+			}
+		}
+		cs.Messages += len(page)
+
+		if e.files {
+			downloaded, err := e.downloadFiles(msgs)
+			if err != nil {
+				return summary, err
+			}
+			cs.Files += downloaded
+		}
+
+		if err := writeDays(filepath.Join(e.out, dir), msgs); err != nil {
+			return summary, err
+		}
+
+		for _, m := range page {
+			if latest == "" || client.CompareTimestamps(m.TS, latest) < 0 {
+				latest = m.TS
+			}
+		}
+		cs.Cursor = latest
+		if err := e.state.save(); err != nil {
+			return summary, fmt.Errorf("writing export progress: %w", err)
+		}
+	}
+
+	cs.Done = true
+	if err := e.state.save(); err != nil {
+		return summary, fmt.Errorf("writing export progress: %w", err)
+	}
+
+	summary.Messages, summary.Replies, summary.Files = cs.Messages, cs.Replies, cs.Files
+	return summary, nil
+}
+
+// downloadFiles saves the files attached to msgs under __uploads, skipping
+// files already downloaded by an earlier run. A file that can't be downloaded
+// is reported and skipped rather than failing the export.
+func (e *exporter) downloadFiles(msgs []client.Message) (int, error) {
+	count := 0
+	for _, m := range msgs {
+		for _, f := range m.Files {
+			link := f.URLPrivateDownload
+			if link == "" {
+				link = f.URLPrivate
+			}
+			if link == "" || f.ID == "" {
+				continue // Deleted, external or hidden files have nothing to download
+			}
+
+			path := filepath.Join(e.out, uploadsDir, f.ID, f.LocalName())
+			if info, err := os.Stat(path); err == nil && (f.Size == 0 || info.Size() == f.Size) {
+				count++
+				continue
+			}
+
+			err := e.call(func() error { return downloadTo(e.client, link, path) })
+			if errors.Is(err, context.Canceled) {
+				return count, err
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not download file %s (%s): %v\n", f.ID, f.LocalName(), err)
+				continue
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// call runs fn, waiting out and retrying rate limited attempts
+func (e *exporter) call(fn func() error) error {
+	return client.RetryRateLimited(e.ctx, client.MaxRateLimitRetries, fn)
+}
+
+// downloadTo downloads a file to path through a temporary file, so an
+// interrupted download is never mistaken for a complete one
+func downloadTo(c *client.Client, link, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	err = c.DownloadFile(link, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writeDays merges messages into the per-day files in dir, keeping each file
+// sorted oldest first with no duplicates
+func writeDays(dir string, msgs []client.Message) error {
+	byDay := make(map[string][]client.Message)
+	for _, m := range msgs {
+		day := messageDay(m.TS)
+		byDay[day] = append(byDay[day], m)
+	}
+
+	for day, dayMsgs := range byDay {
+		path := filepath.Join(dir, day+".json")
+
+		merged := make(map[string]client.Message)
+		existing, err := readMessages(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range existing {
+			merged[m.TS] = m
+		}
+		for _, m := range dayMsgs {
+			merged[m.TS] = m
+		}
+
+		sorted := make([]client.Message, 0, len(merged))
+		for _, m := range merged {
+			sorted = append(sorted, m)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return client.CompareTimestamps(sorted[i].TS, sorted[j].TS) < 0
+		})
+
+		if err := writeJSON(path, sorted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChannelIndexes adds channels to channels.json, groups.json, dms.json or
+// mpims.json, keeping entries for channels exported by earlier runs
+func writeChannelIndexes(out string, channels []client.Channel) error {
+	byFile := make(map[string][]client.Channel)
+	for _, ch := range channels {
+		name := indexFile(ch)
+		byFile[name] = append(byFile[name], ch)
+	}
+
+	for name, list := range byFile {
+		path := filepath.Join(out, name)
+
+		var existing []client.Channel
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &existing); err != nil {
+				return fmt.Errorf("parsing %s: %w", path, err)
+			}
+		}
+
+		for _, ch := range list {
+			replaced := false
+			for i := range existing {
+				if existing[i].ID == ch.ID {
+					existing[i] = ch
+					replaced = true
+				}
+			}
+			if !replaced {
+				existing = append(existing, ch)
+			}
+		}
+		if err := writeJSON(path, existing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readMessages reads a day file, returning nothing if it doesn't exist yet
+func readMessages(path string) ([]client.Message, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var msgs []client.Message
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return msgs, nil
+}
+
+// writeJSON writes v to path atomically, indented like Slack's exports
+func writeJSON(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// indexFile returns the export index file that lists a channel
+func indexFile(ch client.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "dms.json"
+	case ch.IsMpIM:
+		return "mpims.json"
+	case ch.IsPrivate:
+		return "groups.json"
+	}
+	return "channels.json"
+}
+
+// channelDir returns the directory a channel's day files are written to.
+// DMs have no name, so their ID is used, as in Slack's exports.
+func channelDir(ch client.Channel) string {
+	if ch.Name == "" || ch.IsIM {
+		return ch.ID
+	}
+	return ch.Name
+}
+
+// messageDay returns the UTC date of a Slack timestamp, as used for day file names
+func messageDay(ts string) string {
+	secs, _, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return "unknown"
+	}
+	return time.Unix(sec, 0).UTC().Format("2006-01-02")
+}
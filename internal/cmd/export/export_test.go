@@ -0,0 +1,282 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// fakeSlack serves a channel with hourly messages, one of which has a thread
+type fakeSlack struct {
+	messages    []map[string]interface{}
+	failAfter   int32 // Fail history calls after this many (0 = never)
+	rateLimited int32 // Rate limit this many history calls first
+	calls       int32
+	latests     []string
+	fileURL     string
+}
+
+func newFakeSlack(n int) *fakeSlack {
+	f := &fakeSlack{}
+	for i := 0; i < n; i++ {
+		m := map[string]interface{}{
+			"type": "message",
+			"user": "U001",
+			"text": fmt.Sprintf("message %d", i),
+			"ts":   fmt.Sprintf("%d.000100", 1700000000+i*3600),
+		}
+		if i == 3 {
+			m["reply_count"] = 2
+			m["thread_ts"] = m["ts"]
+		}
+		f.messages = append(f.messages, m)
+	}
+	return f
+}
+
+func (f *fakeSlack) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "project-x", "created": 1600000000},
+			})
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"members": []map[string]interface{}{{"id": "U001", "name": "alice"}},
+			})
+		case "/conversations.history":
+			if atomic.AddInt32(&f.rateLimited, -1) >= 0 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			call := atomic.AddInt32(&f.calls, 1)
+			if f.failAfter > 0 && call > f.failAfter {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "internal_error"})
+				return
+			}
+			latest := r.URL.Query().Get("latest")
+			f.latests = append(f.latests, latest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": f.page(latest, r.URL.Query().Get("limit"))})
+		case "/conversations.replies":
+			ts := r.URL.Query().Get("ts")
+			assert.Equal(t, "1700010800.000100", ts)
+			file := map[string]interface{}{"id": "F001", "name": "notes.txt", "size": 5, "url_private_download": f.fileURL}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U001", "text": "parent", "ts": ts, "thread_ts": ts, "reply_count": 2},
+					{"type": "message", "user": "U002", "text": "reply 1", "ts": "1700010900.000100", "thread_ts": ts},
+					{"type": "message", "user": "U002", "text": "reply 2", "ts": "1700100000.000100", "thread_ts": ts, "files": []interface{}{file}},
+				},
+			})
+		case "/files/notes.txt":
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte("hello"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+}
+
+// page returns up to limit messages older than latest, newest first
+func (f *fakeSlack) page(latest, limit string) []map[string]interface{} {
+	var n int
+	_, _ = fmt.Sscanf(limit, "%d", &n)
+	var page []map[string]interface{}
+	for i := len(f.messages) - 1; i >= 0 && len(page) < n; i-- {
+		ts := f.messages[i]["ts"].(string)
+		if latest == "" || client.CompareTimestamps(ts, latest) < 0 {
+			page = append(page, f.messages[i])
+		}
+	}
+	return page
+}
+
+// exported reads every message in the channel's day files
+func exported(t *testing.T, dir string) []client.Message {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "project-x", "*.json"))
+	require.NoError(t, err)
+	sort.Strings(files)
+
+	var all []client.Message
+	for _, path := range files {
+		msgs, err := readMessages(path)
+		require.NoError(t, err)
+		all = append(all, msgs...)
+	}
+	return all
+}
+
+func TestRunExport_WritesSlackLayout(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack(250)
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+	fake.fileURL = server.URL + "/files/notes.txt"
+
+	out := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &exportOptions{out: out, files: true}
+	require.NoError(t, runExport(context.Background(), []string{"C123"}, opts, c))
+
+	var channels []client.Channel
+	data, err := os.ReadFile(filepath.Join(out, "channels.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &channels))
+	require.Len(t, channels, 1)
+	assert.Equal(t, "project-x", channels[0].Name)
+
+	_, err = os.Stat(filepath.Join(out, "users.json"))
+	require.NoError(t, err)
+
+	msgs := exported(t, out)
+	assert.Len(t, msgs, 252, "250 messages plus 2 thread replies")
+	for i := 1; i < len(msgs); i++ {
+		assert.Less(t, client.CompareTimestamps(msgs[i-1].TS, msgs[i].TS), 0, "messages must be sorted with no duplicates")
+	}
+
+	day, err := readMessages(filepath.Join(out, "project-x", "2023-11-14.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "message 0", day[0].Text)
+
+	content, err := os.ReadFile(filepath.Join(out, uploadsDir, "F001", "notes.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	assert.Contains(t, buf.String(), "#project-x: 250 messages, 2 thread replies, 1 files")
+
+	st, err := loadState(out)
+	require.NoError(t, err)
+	assert.True(t, st.Channels["C123"].Done)
+	assert.Equal(t, 1, st.Channels["C123"].Files)
+}
+
+func TestRunExport_ResumesAfterFailure(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack(250)
+	fake.failAfter = 1
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	out := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &exportOptions{out: out}
+
+	err := runExport(context.Background(), []string{"C123"}, opts, c)
+	require.Error(t, err)
+	assert.Len(t, exported(t, out), 200, "the first page was written")
+
+	st, err := loadState(out)
+	require.NoError(t, err)
+	cursor := st.Channels["C123"].Cursor
+	assert.Equal(t, "1700180000.000100", cursor)
+
+	// The second run continues from the saved cursor
+	fake.failAfter = 0
+	fake.latests = nil
+	require.NoError(t, runExport(context.Background(), []string{"C123"}, opts, c))
+	assert.Equal(t, cursor, fake.latests[0])
+	assert.Len(t, exported(t, out), 252)
+
+	// A finished export is skipped
+	fake.latests = nil
+	require.NoError(t, runExport(context.Background(), []string{"C123"}, opts, c))
+	assert.Empty(t, fake.latests)
+}
+
+func TestRunExport_ResumesWithRelativeSince(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack(250)
+	fake.failAfter = 1
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	out := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &exportOptions{out: out, since: "520 weeks ago"}
+
+	err := runExport(context.Background(), []string{"C123"}, opts, c)
+	require.Error(t, err)
+
+	// Pretend the first run was a minute ago, so "520 weeks ago" now
+	// resolves to a later time than it did then
+	st, err := loadState(out)
+	require.NoError(t, err)
+	cs := st.Channels["C123"]
+	sec, err := strconv.ParseInt(strings.TrimSuffix(cs.Oldest, ".000000"), 10, 64)
+	require.NoError(t, err)
+	cs.Oldest = fmt.Sprintf("%d.000000", sec-60)
+	require.NoError(t, st.save())
+
+	fake.failAfter = 0
+	fake.latests = nil
+	require.NoError(t, runExport(context.Background(), []string{"C123"}, opts, c))
+	assert.Equal(t, cs.Cursor, fake.latests[0], "the export resumes from the saved cursor")
+	assert.Len(t, exported(t, out), 252)
+
+	st, err = loadState(out)
+	require.NoError(t, err)
+	assert.Equal(t, cs.Oldest, st.Channels["C123"].Oldest, "the first run's bound is kept")
+}
+
+func TestRunExport_RetriesRateLimits(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack(5)
+	fake.rateLimited = 2
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+	fake.fileURL = server.URL + "/files/notes.txt"
+
+	out := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runExport(context.Background(), []string{"C123"}, &exportOptions{out: out}, c))
+	assert.Len(t, exported(t, out), 7)
+}
+
+func TestRunExport_NewRangeStartsOver(t *testing.T) {
+	st := &state{Channels: map[string]*channelState{
+		"C123": {Name: "project-x", Oldest: "1.000000", Cursor: "5.000000", Done: true},
+	}}
+
+	cs := st.channel("C123", "project-x", "2.000000", "", "2.000000", "")
+	assert.False(t, cs.Done)
+	assert.Empty(t, cs.Cursor)
+}
+
+func TestIndexFile(t *testing.T) {
+	assert.Equal(t, "channels.json", indexFile(client.Channel{}))
+	assert.Equal(t, "groups.json", indexFile(client.Channel{IsPrivate: true}))
+	assert.Equal(t, "dms.json", indexFile(client.Channel{IsIM: true}))
+	assert.Equal(t, "mpims.json", indexFile(client.Channel{IsMpIM: true, IsPrivate: true}))
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// stateFile is the name of the progress file kept in the export directory
+const stateFile = ".slck-export.json"
+
+// state records how far each channel's export got, so an interrupted export
+// resumes where it stopped instead of starting over
+type state struct {
+	path     string
+	Channels map[string]*channelState `json:"channels"`
+}
+
+// channelState is the export progress of one channel. History is exported
+// newest first, so Cursor is the oldest message written so far.
+type channelState struct {
+	Name     string `json:"name"`
+	Since    string `json:"since,omitempty"`
+	Until    string `json:"until,omitempty"`
+	Oldest   string `json:"oldest,omitempty"`
+	Latest   string `json:"latest,omitempty"`
+	Cursor   string `json:"cursor,omitempty"`
+	Done     bool   `json:"done"`
+	Messages int    `json:"messages"`
+	Replies  int    `json:"replies"`
+	Files    int    `json:"files"`
+}
+
+// loadState reads the progress file in dir, returning empty state if there isn't one
+func loadState(dir string) (*state, error) {
+	s := &state{path: filepath.Join(dir, stateFile), Channels: make(map[string]*channelState)}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading export progress: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing export progress %s: %w", s.path, err)
+	}
+	if s.Channels == nil {
+		s.Channels = make(map[string]*channelState)
+	}
+	return s, nil
+}
+
+// channel returns the progress of a channel, starting over if it was
+// exported earlier with a different time range. The range is the same if
+// --since and --until were given as the same text, so a relative bound such
+// as "30 days ago" keeps the times it resolved to on the first run.
+func (s *state) channel(id, name, since, until, oldest, latest string) *channelState {
+	cs, ok := s.Channels[id]
+	sameFlags := ok && cs.Since == since && cs.Until == until
+	sameRange := ok && cs.Oldest == oldest && cs.Latest == latest
+	if !sameFlags && !sameRange {
+		cs = &channelState{Oldest: oldest, Latest: latest}
+		s.Channels[id] = cs
+	}
+	cs.Name, cs.Since, cs.Until = name, since, until
+	return cs
+}
+
+// save writes the progress file atomically
+func (s *state) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
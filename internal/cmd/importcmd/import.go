@@ -0,0 +1,516 @@
+// Package importcmd implements the import command, which replays archived
+// messages into a channel.
+package importcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+const (
+	// maxUsernameLength is the longest username Slack shows
+	maxUsernameLength = 80
+
+	// defaultUsername is the --username template
+	defaultUsername = "{author} ({date})"
+)
+
+type importOptions struct {
+	to        string
+	from      string
+	users     string
+	username  string
+	iconEmoji string
+	iconURL   string
+	noFiles   bool
+	delay     time.Duration
+	progress  string
+	dryRun    bool
+}
+
+// importSummary reports what an import posted in JSON mode
+type importSummary struct {
+	Source          string `json:"source"`
+	Channel         string `json:"channel"`
+	DryRun          bool   `json:"dry_run,omitempty"`
+	Messages        int    `json:"messages"`
+	Replies         int    `json:"replies"`
+	Files           int    `json:"files"`
+	AlreadyImported int    `json:"already_imported"`
+	Skipped         int    `json:"skipped"`
+}
+
+// NewCmd creates the import command
+func NewCmd() *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import <export-dir|jsonl> --to <channel>",
+		Short: "Replay archived messages into a channel",
+		Long: `Post archived messages into a channel, oldest first, for example to move a
+channel to another workspace.
+
+The source can be a directory written by slck export (or Slack's own
+export), or a file of messages, one JSON message per line, such as a
+channel's messages.jsonl in the slck sync mirror. If an export has several
+channels, pick one with --from.
+
+Each message is posted under its original author's name and date, set by
+the --username template ({author}, {date} and {time} are filled in), with
+their avatar when the archive has it. This needs the chat:write.customize
+scope; with --username "" messages are posted under the app's name with the
+author and date at the start of the text instead. User mentions become
+names, and @here, @channel, @everyone and user group mentions are posted as
+plain text, so replaying history doesn't notify anyone.
+
+Threads are recreated: replies are posted in the thread of the copy of their
+parent. Files in the export's __uploads directory (slck export --files) are
+uploaded again right after their message.
+
+Progress is saved after every message, so an interrupted import picks up
+where it left off when run again with the same source and channel, without
+posting anything twice. Posts are spaced by --delay, and rate limited
+requests are retried after the wait Slack asks for.
+
+Examples:
+  slck import ./slack-export --from incidents --to incidents-archive --dry-run
+  slck import ./slack-export --from incidents --to incidents-archive
+  slck import messages.jsonl --to C1234567890 --users users.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runImport(ctx, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.to, "to", "", "Channel to post the messages in (required)")
+	cmd.Flags().StringVar(&opts.from, "from", "", "Channel to import from an export with several channels")
+	cmd.Flags().StringVar(&opts.users, "users", "", "Users file for author names (an export's users.json, or a map of user IDs to names)")
+	cmd.Flags().StringVar(&opts.username, "username", defaultUsername, "Name to post each message under")
+	cmd.Flags().StringVar(&opts.iconEmoji, "icon-emoji", "", "Emoji to post every message with instead of the author's avatar")
+	cmd.Flags().StringVar(&opts.iconURL, "icon-url", "", "Image URL to post every message with instead of the author's avatar")
+	cmd.Flags().BoolVar(&opts.noFiles, "no-files", false, "Don't upload files again")
+	cmd.Flags().DurationVar(&opts.delay, "delay", time.Second, "Time between posts")
+	cmd.Flags().StringVar(&opts.progress, "progress", "", "Progress file (default: import-progress.json in the config directory)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be posted without posting anything")
+
+	return cmd
+}
+
+func runImport(ctx context.Context, path string, opts *importOptions, c *client.Client) error {
+	if opts.to == "" {
+		return fmt.Errorf("--to is required")
+	}
+	if opts.iconEmoji != "" && opts.iconURL != "" {
+		return fmt.Errorf("only one of --icon-emoji and --icon-url can be used")
+	}
+	if opts.delay < 0 {
+		return fmt.Errorf("--delay can't be negative")
+	}
+
+	src, err := loadSource(path, opts.from, opts.users)
+	if err != nil {
+		return err
+	}
+
+	progressPath := opts.progress
+	if progressPath == "" {
+		progressPath = defaultProgressPath()
+	}
+	prog, err := loadProgress(progressPath)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveChannel(opts.to)
+	if err != nil {
+		return err
+	}
+
+	im := &importer{
+		ctx:       ctx,
+		client:    c,
+		src:       src,
+		users:     client.NewStaticUserResolver(src.names),
+		opts:      opts,
+		channelID: channelID,
+		progress:  prog,
+		state:     prog.state(src.path, channelID),
+		summary:   importSummary{Source: src.path, Channel: channelID, DryRun: opts.dryRun},
+	}
+
+	err = im.run()
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("import interrupted; run the same command again to resume")
+	}
+	if err != nil {
+		return err
+	}
+
+	s := im.summary
+	if output.IsJSON() {
+		return output.PrintJSON(s)
+	}
+
+	counts := fmt.Sprintf("%d %s, %d %s and %d %s",
+		s.Messages, plural(s.Messages, "message", "messages"),
+		s.Replies, plural(s.Replies, "reply", "replies"),
+		s.Files, plural(s.Files, "file", "files"))
+	if opts.dryRun {
+		output.Printf("Dry run: would import %s into %s\n", counts, opts.to)
+	} else {
+		output.Printf("Imported %s into %s\n", counts, opts.to)
+	}
+	if s.AlreadyImported > 0 {
+		output.Printf("%d already imported by an earlier run\n", s.AlreadyImported)
+	}
+	if s.Skipped > 0 {
+		output.Printf("%d skipped (channel events and empty messages)\n", s.Skipped)
+	}
+	return nil
+}
+
+// importer posts a source's messages into a channel
+type importer struct {
+	ctx       context.Context
+	client    *client.Client
+	src       *source
+	users     *client.UserResolver
+	opts      *importOptions
+	channelID string
+	progress  *progress
+	state     *importState
+	summary   importSummary
+}
+
+// run posts every message not already imported, oldest first
+func (im *importer) run() error {
+	for _, m := range im.src.messages {
+		if err := im.ctx.Err(); err != nil {
+			return err
+		}
+
+		text := im.text(m)
+		if !importable(m) || text == "" {
+			im.summary.Skipped++
+			continue
+		}
+
+		// Replies go in the thread of their parent's copy. A reply whose
+		// parent isn't in the source starts the thread itself.
+		reply := mirror.IsReply(m)
+		threadTS := ""
+		if reply {
+			threadTS = im.state.Posted[m.ThreadTS]
+		}
+
+		if posted, ok := im.state.Posted[m.TS]; ok {
+			im.summary.AlreadyImported++
+			if err := im.uploadFiles(m, fileThread(reply, threadTS, posted)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if im.opts.dryRun {
+			im.preview(m, text, reply)
+			continue
+		}
+
+		var msg *client.Message
+		if err := im.call(func() (err error) {
+			msg, err = im.client.SendMessageAs(im.channelID, text, threadTS, im.persona(m))
+			return err
+		}); err != nil {
+			return client.WrapError(fmt.Sprintf("post message %s", m.TS), err)
+		}
+
+		im.state.Posted[m.TS] = msg.TS
+		if reply && threadTS == "" {
+			im.state.Posted[m.ThreadTS] = msg.TS
+		}
+		if err := im.progress.save(); err != nil {
+			return fmt.Errorf("writing import progress: %w", err)
+		}
+		if reply {
+			im.summary.Replies++
+		} else {
+			im.summary.Messages++
+		}
+
+		if err := im.uploadFiles(m, fileThread(reply, threadTS, msg.TS)); err != nil {
+			return err
+		}
+		if err := im.sleep(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text returns what to post for a message, or nothing if it has no content
+func (im *importer) text(m client.Message) string {
+	text := im.users.ResolveMentions(m.Text)
+	if text == "" {
+		var fallbacks []string
+		for _, a := range m.Attachments {
+			if a.Fallback != "" {
+				fallbacks = append(fallbacks, a.Fallback)
+			}
+		}
+		text = strings.Join(fallbacks, "\n")
+	}
+	text = disarmMentions(text)
+	if text == "" && len(m.Files) > 0 {
+		text = fmt.Sprintf("_shared %d %s_", len(m.Files), plural(len(m.Files), "file", "files"))
+	}
+	if text == "" {
+		return ""
+	}
+
+	if im.opts.username == "" {
+		return fmt.Sprintf("*%s* (%s)\n%s", im.users.Author(m), messageTime(m.TS).Format("2006-01-02 15:04"), text)
+	}
+	return text
+}
+
+// specialMention matches Slack's <!…> tokens, such as <!here>, <!channel|@channel>
+// and <!subteam^S123|@oncall>, capturing the target and any label
+var specialMention = regexp.MustCompile(`<!([^>|]*)(?:\|([^>]*))?>`)
+
+// disarmMentions turns special mentions into plain text, such as @here, so
+// replaying history into a live channel doesn't notify anyone
+func disarmMentions(text string) string {
+	return specialMention.ReplaceAllStringFunc(text, func(token string) string {
+		m := specialMention.FindStringSubmatch(token)
+		target, label := m[1], m[2]
+		switch {
+		case strings.HasPrefix(target, "date^"):
+			return label // Dates always carry a fallback
+		case label != "":
+			return "@" + strings.TrimPrefix(label, "@")
+		default:
+			return "@" + strings.TrimPrefix(target, "subteam^")
+		}
+	})
+}
+
+// persona returns the name and icon to post a message under
+func (im *importer) persona(m client.Message) client.Persona {
+	if im.opts.username == "" {
+		return client.Persona{}
+	}
+
+	t := messageTime(m.TS)
+	name := strings.NewReplacer(
+		"{author}", im.users.Author(m),
+		"{date}", t.Format("2006-01-02"),
+		"{time}", t.Format("15:04"),
+	).Replace(im.opts.username)
+	if utf8.RuneCountInString(name) > maxUsernameLength {
+		name = string([]rune(name)[:maxUsernameLength])
+	}
+
+	p := client.Persona{Username: name, IconEmoji: im.opts.iconEmoji, IconURL: im.opts.iconURL}
+	if p.IconEmoji == "" && p.IconURL == "" {
+		p.IconURL = im.src.icons[m.User]
+	}
+	return p
+}
+
+// preview prints what a dry run would post for a message
+func (im *importer) preview(m client.Message, text string, reply bool) {
+	if reply {
+		im.summary.Replies++
+	} else {
+		im.summary.Messages++
+	}
+	for _, f := range m.Files {
+		if _, err := im.localFile(f); err == nil && !im.opts.noFiles {
+			im.summary.Files++
+		}
+	}
+	if output.IsJSON() {
+		return
+	}
+
+	indent := ""
+	if reply {
+		indent = "    "
+	}
+	name := im.persona(m).Username
+	if name == "" {
+		name = im.users.Author(m)
+	}
+	text = strings.ReplaceAll(text, "\n", "\n"+indent+"  ")
+	output.Printf("%s[%s] %s: %s\n", indent, messageTime(m.TS).Format("2006-01-02 15:04"), name, text)
+	for _, f := range m.Files {
+		note := ""
+		if im.opts.noFiles {
+			note = " (not uploaded: --no-files)"
+		} else if _, err := im.localFile(f); err != nil {
+			note = " (not in the export, skipped)"
+		}
+		output.Printf("%s  [file] %s%s\n", indent, f.LocalName(), note)
+	}
+}
+
+// uploadFiles uploads the files attached to a message from the export,
+// skipping files already uploaded and files the export doesn't have
+func (im *importer) uploadFiles(m client.Message, threadTS string) error {
+	if im.opts.noFiles || im.opts.dryRun {
+		return nil
+	}
+	for _, f := range m.Files {
+		if f.ID == "" || im.state.Files[f.ID] {
+			continue
+		}
+		path, err := im.localFile(f)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: file %s (%s) isn't in the export, skipped (export with --files to include it)\n", f.ID, f.LocalName())
+			continue
+		}
+		if err := im.uploadFile(f, path, threadTS); err != nil {
+			return err
+		}
+
+		im.state.Files[f.ID] = true
+		if err := im.progress.save(); err != nil {
+			return fmt.Errorf("writing import progress: %w", err)
+		}
+		im.summary.Files++
+		if err := im.sleep(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadFile uploads a file and shares it in the channel or thread
+func (im *importer) uploadFile(f client.File, path, threadTS string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	name := f.LocalName()
+
+	var upload *client.UploadURLResponse
+	if err := im.call(func() (err error) {
+		upload, err = im.client.GetUploadURLExternal(name, info.Size())
+		return err
+	}); err != nil {
+		return client.WrapError(fmt.Sprintf("get upload URL for %s", name), err)
+	}
+
+	if err := im.call(func() error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return im.client.UploadFileToURL(upload.UploadURL, file)
+	}); err != nil {
+		return client.WrapError(fmt.Sprintf("upload %s", name), err)
+	}
+
+	title := f.Title
+	if title == "" {
+		title = name
+	}
+	files := []client.CompleteUploadExternalFile{{ID: upload.FileID, Title: title}}
+	if err := im.call(func() error {
+		return im.client.CompleteUploadExternal(files, im.channelID, threadTS, "")
+	}); err != nil {
+		return client.WrapError(fmt.Sprintf("complete upload of %s", name), err)
+	}
+	return nil
+}
+
+// localFile returns where the export keeps a file
+func (im *importer) localFile(f client.File) (string, error) {
+	if im.src.uploads == "" || f.ID == "" {
+		return "", fs.ErrNotExist
+	}
+	path := filepath.Join(im.src.uploads, f.ID, f.LocalName())
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// call runs fn, waiting out and retrying rate limited attempts
+func (im *importer) call(fn func() error) error {
+	return client.RetryRateLimited(im.ctx, client.MaxRateLimitRetries, fn)
+}
+
+// sleep waits --delay between posts
+func (im *importer) sleep() error {
+	if im.opts.delay == 0 {
+		return im.ctx.Err()
+	}
+	if !client.SleepContext(im.ctx, im.opts.delay) {
+		return im.ctx.Err()
+	}
+	return nil
+}
+
+// importable reports whether a message is content worth replaying, rather
+// than a channel event such as a join or topic change
+func importable(m client.Message) bool {
+	switch m.Subtype {
+	case "", "bot_message", "file_share", "me_message", "thread_broadcast":
+		return true
+	}
+	return false
+}
+
+// fileThread returns where a message's files are shared: in its thread if
+// it's a reply, otherwise in the channel right after it
+func fileThread(reply bool, threadTS, posted string) string {
+	if !reply {
+		return ""
+	}
+	if threadTS == "" {
+		return posted
+	}
+	return threadTS
+}
+
+// messageTime returns the local time of a Slack timestamp
+func messageTime(ts string) time.Time {
+	secs, _, _ := strings.Cut(ts, ".")
+	sec, _ := strconv.ParseInt(secs, 10, 64)
+	return time.Unix(sec, 0)
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,286 @@
+package importcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// post is a chat.postMessage or files.completeUploadExternal call received by fakeSlack
+type post struct {
+	TS       string
+	Text     string
+	ThreadTS string
+	Username string
+	IconURL  string
+	FileID   string
+}
+
+// fakeSlack accepts posts and uploads, giving each post a new timestamp
+type fakeSlack struct {
+	posts       []post
+	uploaded    []string
+	rateLimited int32 // Rate limit this many posts first
+	failAfter   int   // Fail posts after this many (0 = never)
+	next        int
+	serverURL   string
+}
+
+func (f *fakeSlack) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			if atomic.AddInt32(&f.rateLimited, -1) >= 0 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			if f.failAfter > 0 && len(f.posts) >= f.failAfter {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "internal_error"})
+				return
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.next++
+			p := post{TS: fmt.Sprintf("1800000000.%06d", f.next)}
+			p.Text, _ = body["text"].(string)
+			p.ThreadTS, _ = body["thread_ts"].(string)
+			p.Username, _ = body["username"].(string)
+			p.IconURL, _ = body["icon_url"].(string)
+			f.posts = append(f.posts, p)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": p.TS, "channel": "C999"})
+		case "/files.getUploadURLExternal":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true, "upload_url": f.serverURL + "/upload", "file_id": "FNEW" + r.URL.Query().Get("filename"),
+			})
+		case "/upload":
+			data, _ := io.ReadAll(r.Body)
+			f.uploaded = append(f.uploaded, string(data))
+		case "/files.completeUploadExternal":
+			var body struct {
+				Files    []client.CompleteUploadExternalFile `json:"files"`
+				ThreadTS string                              `json:"thread_ts"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.posts = append(f.posts, post{FileID: body.Files[0].ID, ThreadTS: body.ThreadTS})
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+}
+
+func newFakeSlack(t *testing.T) (*fakeSlack, *client.Client) {
+	f := &fakeSlack{}
+	server := httptest.NewServer(f.handler(t))
+	t.Cleanup(server.Close)
+	f.serverURL = server.URL
+	return f, client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+// writeExport writes an export of #incidents, with a thread and a file, and #random
+func writeExport(t *testing.T) string {
+	root := t.TempDir()
+	writeJSON(t, filepath.Join(root, "users.json"), []map[string]interface{}{
+		{"id": "U001", "name": "alice", "profile": map[string]interface{}{"display_name": "Alice", "image_72": "https://avatars.example/alice.png"}},
+		{"id": "U002", "name": "bob", "profile": map[string]interface{}{}},
+	})
+	writeJSON(t, filepath.Join(root, "incidents", "2023-11-14.json"), []map[string]interface{}{
+		{"ts": "1700000000.000100", "user": "U001", "subtype": "channel_join", "text": "<@U001> has joined the channel"},
+		{"ts": "1700000100.000100", "user": "U001", "text": "Site is down, <@U002> can you look?", "thread_ts": "1700000100.000100", "reply_count": 1},
+		{"ts": "1700000200.000100", "user": "U002", "text": "Rolled back", "thread_ts": "1700000100.000100",
+			"files": []map[string]interface{}{{"id": "F1", "name": "graph.png", "title": "Error rate"}}},
+	})
+	writeJSON(t, filepath.Join(root, "incidents", "2023-11-15.json"), []map[string]interface{}{
+		{"ts": "1700090000.000100", "user": "U002", "text": "All clear"},
+	})
+	writeJSON(t, filepath.Join(root, "random", "2023-11-14.json"), []map[string]interface{}{
+		{"ts": "1700000000.000200", "user": "U002", "text": "Lunch?"},
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(root, uploadsDir, "F1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, uploadsDir, "F1", "graph.png"), []byte("png bytes"), 0600))
+	return root
+}
+
+func writeJSON(t *testing.T, path string, v interface{}) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestRunImport_Export(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	fake, c := newFakeSlack(t)
+	root := writeExport(t)
+	opts := &importOptions{to: "C999", from: "incidents", username: defaultUsername, progress: filepath.Join(t.TempDir(), "progress.json")}
+	require.NoError(t, runImport(context.Background(), root, opts, c))
+
+	require.Len(t, fake.posts, 4, "three messages and a file; the join is skipped")
+	day := time.Unix(1700000100, 0).Format("2006-01-02")
+
+	parent := fake.posts[0]
+	assert.Equal(t, "Site is down, @bob can you look?", parent.Text)
+	assert.Equal(t, "Alice ("+day+")", parent.Username)
+	assert.Equal(t, "https://avatars.example/alice.png", parent.IconURL)
+	assert.Empty(t, parent.ThreadTS)
+
+	assert.Equal(t, "Rolled back", fake.posts[1].Text)
+	assert.Equal(t, parent.TS, fake.posts[1].ThreadTS, "replies go in the copied thread")
+	assert.Equal(t, "FNEWgraph.png", fake.posts[2].FileID)
+	assert.Equal(t, parent.TS, fake.posts[2].ThreadTS, "files follow their message")
+	assert.Equal(t, []string{"png bytes"}, fake.uploaded)
+
+	assert.Equal(t, "All clear", fake.posts[3].Text)
+	assert.Empty(t, fake.posts[3].ThreadTS)
+
+	assert.Contains(t, buf.String(), "Imported 2 messages, 1 reply and 1 file into C999")
+	assert.Contains(t, buf.String(), "1 skipped")
+}
+
+func TestRunImport_DisarmsBroadcasts(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake, c := newFakeSlack(t)
+	root := t.TempDir()
+	writeJSON(t, filepath.Join(root, "incidents", "2023-11-14.json"), []map[string]interface{}{
+		{"ts": "1700000100.000100", "user": "U001",
+			"text": "<!channel> site is down, <!here|here> <!everyone> <!subteam^S123|@oncall> <!subteam^S456> since <!date^1700000000^{time}|22:13>"},
+	})
+	opts := &importOptions{to: "C999", from: "incidents", username: defaultUsername, progress: filepath.Join(t.TempDir(), "progress.json")}
+	require.NoError(t, runImport(context.Background(), root, opts, c))
+
+	require.Len(t, fake.posts, 1)
+	assert.Equal(t, "@channel site is down, @here @everyone @oncall @S456 since 22:13", fake.posts[0].Text)
+	assert.NotContains(t, fake.posts[0].Text, "<!")
+}
+
+func TestRunImport_Resumes(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake, c := newFakeSlack(t)
+	fake.failAfter = 1
+	root := writeExport(t)
+	opts := &importOptions{to: "C999", from: "incidents", username: defaultUsername, progress: filepath.Join(t.TempDir(), "progress.json")}
+
+	err := runImport(context.Background(), root, opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "internal_error")
+	require.Len(t, fake.posts, 1)
+
+	fake.failAfter = 0
+	var buf bytes.Buffer
+	output.Writer = &buf
+	require.NoError(t, runImport(context.Background(), root, opts, c))
+
+	require.Len(t, fake.posts, 4, "nothing is posted twice")
+	assert.Equal(t, fake.posts[0].TS, fake.posts[1].ThreadTS, "the thread is found from the progress file")
+	assert.Contains(t, buf.String(), "1 already imported")
+
+	// Running again posts nothing, even given the channel's directory
+	opts.from = ""
+	require.NoError(t, runImport(context.Background(), filepath.Join(root, "incidents"), opts, c))
+	assert.Len(t, fake.posts, 4)
+}
+
+func TestRunImport_DryRun(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	fake, c := newFakeSlack(t)
+	root := writeExport(t)
+	progress := filepath.Join(t.TempDir(), "progress.json")
+	opts := &importOptions{to: "C999", from: "incidents", username: defaultUsername, progress: progress, dryRun: true}
+	require.NoError(t, runImport(context.Background(), root, opts, c))
+
+	assert.Empty(t, fake.posts)
+	_, err := os.Stat(progress)
+	assert.True(t, os.IsNotExist(err), "a dry run records no progress")
+
+	got := buf.String()
+	assert.Contains(t, got, "Alice (")
+	assert.Contains(t, got, "    [")
+	assert.Contains(t, got, "[file] graph.png\n")
+	assert.Contains(t, got, "Dry run: would import 2 messages, 1 reply and 1 file into C999")
+}
+
+func TestRunImport_JSONL(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake, c := newFakeSlack(t)
+	fake.rateLimited = 2
+	dir := t.TempDir()
+	path := filepath.Join(dir, "messages.jsonl")
+	lines := []string{
+		`{"ts":"1700000200.000100","user":"U002","text":"First reply","thread_ts":"1700000100.000100"}`,
+		``,
+		`{"ts":"1700000300.000100","user":"U001","text":"Second reply","thread_ts":"1700000100.000100","files":[{"id":"F1","name":"a.txt"}]}`,
+	}
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600))
+	users := filepath.Join(dir, "users.json")
+	writeJSON(t, users, map[string]string{"U001": "alice", "U002": "bob"})
+
+	opts := &importOptions{to: "C999", users: users, username: "", progress: filepath.Join(dir, "progress.json")}
+	require.NoError(t, runImport(context.Background(), path, opts, c))
+
+	require.Len(t, fake.posts, 2, "the file isn't in an export, so it's skipped")
+	assert.Empty(t, fake.posts[0].Username)
+	assert.True(t, strings.HasPrefix(fake.posts[0].Text, "*bob* ("), fake.posts[0].Text)
+	assert.True(t, strings.HasSuffix(fake.posts[0].Text, "\nFirst reply"))
+	assert.Empty(t, fake.posts[0].ThreadTS, "a reply without its parent starts the thread")
+	assert.Equal(t, fake.posts[0].TS, fake.posts[1].ThreadTS)
+}
+
+func TestRunImport_Validation(t *testing.T) {
+	root := writeExport(t)
+
+	err := runImport(context.Background(), root, &importOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--to is required")
+
+	err = runImport(context.Background(), root, &importOptions{to: "C999", iconEmoji: ":x:", iconURL: "https://x"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of")
+
+	err = runImport(context.Background(), root, &importOptions{to: "C999"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has 2 channels (incidents, random); choose one with --from")
+
+	err = runImport(context.Background(), root, &importOptions{to: "C999", from: "general"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `channel "general" not found`)
+}
+
+func TestLoadSource_ChannelDir(t *testing.T) {
+	root := writeExport(t)
+
+	src, err := loadSource(filepath.Join(root, "incidents"), "", "")
+	require.NoError(t, err)
+	require.Len(t, src.messages, 4)
+	assert.Equal(t, "1700000000.000100", src.messages[0].TS, "oldest first")
+	assert.Equal(t, "Alice", src.names["U001"], "users.json is read from the export root")
+	assert.Equal(t, filepath.Join(root, uploadsDir), src.uploads)
+}
@@ -0,0 +1,89 @@
+package importcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+)
+
+// defaultProgressPath returns where import progress is kept by default
+func defaultProgressPath() string {
+	return filepath.Join(keychain.ConfigDir(), "import-progress.json")
+}
+
+// progress records what each import has posted, so an interrupted import
+// resumes where it stopped instead of posting messages twice
+type progress struct {
+	path    string
+	Imports map[string]*importState `json:"imports"`
+}
+
+// importState is the progress of importing one source into one channel
+type importState struct {
+	Source  string `json:"source"`
+	Channel string `json:"channel"`
+	// Posted maps the timestamps of imported messages to their copies. A
+	// thread whose parent wasn't imported maps the parent's timestamp to the
+	// reply that stands in for it.
+	Posted map[string]string `json:"posted"`
+	// Files are the IDs of the original files re-uploaded so far
+	Files map[string]bool `json:"files,omitempty"`
+}
+
+// loadProgress reads the progress file, returning empty progress if there isn't one
+func loadProgress(path string) (*progress, error) {
+	p := &progress{path: path, Imports: make(map[string]*importState)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading import progress: %w", err)
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parsing import progress %s: %w", path, err)
+	}
+	if p.Imports == nil {
+		p.Imports = make(map[string]*importState)
+	}
+	return p, nil
+}
+
+// state returns the progress of importing source into channel
+func (p *progress) state(source, channel string) *importState {
+	key := source + " -> " + channel
+	st, ok := p.Imports[key]
+	if !ok {
+		st = &importState{Source: source, Channel: channel}
+		p.Imports[key] = st
+	}
+	if st.Posted == nil {
+		st.Posted = make(map[string]string)
+	}
+	if st.Files == nil {
+		st.Files = make(map[string]bool)
+	}
+	return st
+}
+
+// save writes the progress file atomically
+func (p *progress) save() error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
@@ -0,0 +1,276 @@
+package importcmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// uploadsDir holds downloaded files in an export made with slck export --files
+const uploadsDir = "__uploads"
+
+// dayFileRegex matches the per-day message files of an export
+var dayFileRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\.json$`)
+
+// source is the archive being imported
+type source struct {
+	// path identifies the source in the progress file
+	path     string
+	messages []client.Message
+	names    map[string]string
+	// icons maps user IDs to their avatar URLs, where the source has them
+	icons map[string]string
+	// uploads is the export's __uploads directory, or empty for JSONL
+	uploads string
+}
+
+// loadSource reads the messages to import from an export directory or a
+// JSONL file, oldest first. from picks the channel in a multi-channel export.
+func loadSource(path, from, usersFile string) (*source, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access %s: %w", path, err)
+	}
+
+	src := &source{names: make(map[string]string), icons: make(map[string]string)}
+	if info.IsDir() {
+		root, dir, err := exportChannelDir(abs, from)
+		if err != nil {
+			return nil, err
+		}
+		if src.messages, err = readDayFiles(dir); err != nil {
+			return nil, err
+		}
+		if err := src.readUsers(filepath.Join(root, "users.json"), true); err != nil {
+			return nil, err
+		}
+		src.path = dir
+		src.uploads = filepath.Join(root, uploadsDir)
+	} else {
+		if from != "" {
+			return nil, fmt.Errorf("--from can only be used with an export directory")
+		}
+		if src.messages, err = readMessagesFile(abs); err != nil {
+			return nil, err
+		}
+		src.path = abs
+	}
+
+	if usersFile != "" {
+		if err := src.readUsers(usersFile, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// Exports also carry each author's profile on their messages, which
+	// covers users missing from users.json
+	for _, m := range src.messages {
+		if m.User == "" {
+			continue
+		}
+		name, icon := messageProfile(m)
+		if _, ok := src.names[m.User]; !ok && name != "" {
+			src.names[m.User] = name
+		}
+		if _, ok := src.icons[m.User]; !ok && icon != "" {
+			src.icons[m.User] = icon
+		}
+	}
+
+	byTS := make(map[string]client.Message, len(src.messages))
+	for _, m := range src.messages {
+		byTS[m.TS] = m
+	}
+	src.messages = src.messages[:0]
+	for _, m := range byTS {
+		src.messages = append(src.messages, m)
+	}
+	sort.Slice(src.messages, func(i, j int) bool {
+		return client.CompareTimestamps(src.messages[i].TS, src.messages[j].TS) < 0
+	})
+	return src, nil
+}
+
+// exportChannelDir finds the channel directory to import in an export. The
+// path can be the export itself or one of its channel directories.
+func exportChannelDir(path, from string) (root, dir string, err error) {
+	if from != "" {
+		dir = filepath.Join(path, strings.TrimPrefix(from, "#"))
+		if _, err := os.Stat(dir); err != nil {
+			return "", "", fmt.Errorf("channel %q not found in export %s", from, path)
+		}
+		return path, dir, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", "", err
+	}
+	var channels []string
+	for _, e := range entries {
+		if !e.IsDir() && dayFileRegex.MatchString(e.Name()) {
+			return filepath.Dir(path), path, nil
+		}
+		if e.IsDir() && e.Name() != uploadsDir && !strings.HasPrefix(e.Name(), ".") {
+			channels = append(channels, e.Name())
+		}
+	}
+
+	switch len(channels) {
+	case 0:
+		return "", "", fmt.Errorf("no channels found in export %s", path)
+	case 1:
+		return path, filepath.Join(path, channels[0]), nil
+	}
+	return "", "", fmt.Errorf("export %s has %d channels (%s); choose one with --from",
+		path, len(channels), strings.Join(channels, ", "))
+}
+
+// readDayFiles reads every day file in an export channel directory
+func readDayFiles(dir string) ([]client.Message, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var messages []client.Message
+	for _, e := range entries {
+		if e.IsDir() || !dayFileRegex.MatchString(e.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var day []client.Message
+		if err := json.Unmarshal(data, &day); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		messages = append(messages, day...)
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages found in %s", dir)
+	}
+	return messages, nil
+}
+
+// readMessagesFile reads messages from a JSONL file, one message per line,
+// or from a JSON array such as the output of slck messages history -o json
+func readMessagesFile(path string) ([]client.Message, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []client.Message
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var m client.Message
+			if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+				return nil, fmt.Errorf("parsing %s line %d: %w", path, line, err)
+			}
+			messages = append(messages, m)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages found in %s", path)
+	}
+	return messages, nil
+}
+
+// readUsers adds user names and avatars from a users file. The file can be
+// an export's users.json or a map of user IDs to names, as kept by slck sync.
+func (src *source) readUsers(path string, optional bool) error {
+	data, err := os.ReadFile(path)
+	if optional && errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var users []client.User
+	if err := json.Unmarshal(data, &users); err == nil {
+		for _, u := range users {
+			src.names[u.ID] = userName(u)
+			if u.Profile.Image72 != "" {
+				src.icons[u.ID] = u.Profile.Image72
+			}
+		}
+		return nil
+	}
+
+	var byID map[string]string
+	if err := json.Unmarshal(data, &byID); err != nil {
+		return fmt.Errorf("parsing %s: expected a list of users or a map of user IDs to names", path)
+	}
+	for id, name := range byID {
+		src.names[id] = name
+	}
+	return nil
+}
+
+// userName returns the name a user is shown by
+func userName(u client.User) string {
+	switch {
+	case u.Profile.DisplayName != "":
+		return u.Profile.DisplayName
+	case u.RealName != "":
+		return u.RealName
+	}
+	return u.Name
+}
+
+// messageProfile returns the author's name and avatar from the user_profile
+// Slack includes on messages in its exports
+func messageProfile(m client.Message) (name, icon string) {
+	raw, ok := m.Extra["user_profile"]
+	if !ok {
+		return "", ""
+	}
+	var profile struct {
+		DisplayName string `json:"display_name"`
+		RealName    string `json:"real_name"`
+		Name        string `json:"name"`
+		Image72     string `json:"image_72"`
+	}
+	if json.Unmarshal(raw, &profile) != nil {
+		return "", ""
+	}
+	switch {
+	case profile.DisplayName != "":
+		name = profile.DisplayName
+	case profile.RealName != "":
+		name = profile.RealName
+	default:
+		name = profile.Name
+	}
+	return name, profile.Image72
+}
@@ -0,0 +1,64 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type channelsOptions struct {
+	dir string
+}
+
+func newChannelsCmd() *cobra.Command {
+	opts := &channelsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "channels",
+		Short: "List mirrored channels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChannels(opts)
+		},
+	}
+
+	addDirFlag(cmd, &opts.dir)
+
+	return cmd
+}
+
+func runChannels(opts *channelsOptions) error {
+	v, err := openView(opts.dir)
+	if err != nil {
+		return err
+	}
+	channels, err := v.store.Channels()
+	if err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(channels)
+	}
+
+	if len(channels) == 0 {
+		output.Println("No channels mirrored yet (run: slck sync <channel>)")
+		return nil
+	}
+
+	headers := []string{"ID", "NAME", "MESSAGES", "REPLIES", "LAST SYNC"}
+	rows := make([][]string, 0, len(channels))
+	for _, ch := range channels {
+		rows = append(rows, []string{
+			ch.ID,
+			ch.Name,
+			fmt.Sprintf("%d", ch.Messages),
+			fmt.Sprintf("%d", ch.Replies),
+			ch.SyncedAt.Local().Format("2006-01-02 15:04"),
+		})
+	}
+	output.Table(headers, rows)
+	return nil
+}
@@ -0,0 +1,106 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type historyOptions struct {
+	dir        string
+	limit      int
+	match      string
+	ignoreCase bool
+	filters    filterOptions
+}
+
+func newHistoryCmd() *cobra.Command {
+	opts := &historyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "history <channel>",
+		Short: "Show a mirrored channel's history, newest first",
+		Long: `Show a mirrored channel's top-level messages, newest first, like
+slck messages history but from the local mirror.
+
+Examples:
+  slck local history incidents --limit 50
+  slck local history incidents --match "rollback|revert" -i
+  slck local history incidents --user alice --since "last monday"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(args[0], opts)
+		},
+	}
+
+	addDirFlag(cmd, &opts.dir)
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum messages to show (0 for all)")
+	cmd.Flags().StringVar(&opts.match, "match", "", "Only messages whose text matches this regex")
+	cmd.Flags().BoolVarP(&opts.ignoreCase, "ignore-case", "i", false, "Match --match case-insensitively")
+	addFilterFlags(cmd, &opts.filters)
+
+	return cmd
+}
+
+func runHistory(channel string, opts *historyOptions) error {
+	text, err := compilePattern(opts.match, opts.ignoreCase)
+	if err != nil {
+		return err
+	}
+	v, err := openView(opts.dir)
+	if err != nil {
+		return err
+	}
+	f, err := opts.filters.compile(text, v.names)
+	if err != nil {
+		return err
+	}
+
+	ch, err := v.store.FindChannel(channel)
+	if err != nil {
+		return err
+	}
+	all, err := v.store.Messages(ch.ID)
+	if err != nil {
+		return err
+	}
+
+	messages := make([]client.Message, 0)
+	for i := len(all) - 1; i >= 0; i-- {
+		if opts.limit > 0 && len(messages) >= opts.limit {
+			break
+		}
+		if !mirror.IsReply(all[i]) && f.match(all[i]) {
+			messages = append(messages, all[i])
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(messages)
+	}
+
+	if len(messages) == 0 {
+		output.Println("No messages found")
+		return nil
+	}
+
+	for _, m := range messages {
+		line := v.line(m, "")
+		if m.ReplyCount > 0 {
+			line += fmt.Sprintf("\n  [%d %s]", m.ReplyCount, plural(m.ReplyCount, "reply", "replies"))
+		}
+		output.Println(line)
+	}
+	return nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
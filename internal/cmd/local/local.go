@@ -0,0 +1,246 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+)
+
+// NewCmd creates the local command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local",
+		Short: "Read and search the local mirror made by slck sync",
+		Long: `Read and search channels mirrored with slck sync, without calling Slack.
+
+Filters can be combined; a message must match all of them:
+  --user        Author's user ID or name (repeatable; any may match)
+  --since       Only messages after this time
+  --until       Only messages before this time
+  --where       field=regex on any field of the message JSON, e.g.
+                subtype=bot_message or reply_count=^[1-9] (repeatable)
+  --has-files   Only messages with files
+
+--since and --until accept Slack timestamps or:
+` + timeparse.Syntax,
+	}
+
+	cmd.AddCommand(newChannelsCmd())
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newThreadCmd())
+	cmd.AddCommand(newSearchCmd())
+
+	return cmd
+}
+
+func addDirFlag(cmd *cobra.Command, dir *string) {
+	cmd.Flags().StringVar(dir, "dir", "", "Mirror directory (default: mirror in the config directory)")
+}
+
+// filterOptions are the message filters shared by the local commands
+type filterOptions struct {
+	users    []string
+	since    string
+	until    string
+	where    []string
+	hasFiles bool
+}
+
+func addFilterFlags(cmd *cobra.Command, opts *filterOptions) {
+	cmd.Flags().StringSliceVar(&opts.users, "user", nil, "Only messages by this user ID or name")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only messages after this time")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only messages before this time")
+	cmd.Flags().StringArrayVar(&opts.where, "where", nil, "Only messages whose field matches a regex (field=regex)")
+	cmd.Flags().BoolVar(&opts.hasFiles, "has-files", false, "Only messages with files")
+}
+
+// filter is a compiled set of filterOptions
+type filter struct {
+	users    map[string]bool
+	names    map[string]string
+	oldest   string
+	latest   string
+	text     *regexp.Regexp
+	where    []whereClause
+	hasFiles bool
+}
+
+// whereClause matches one field of a message's JSON
+type whereClause struct {
+	field string
+	re    *regexp.Regexp
+}
+
+// compile checks the filter options. text, if not nil, must match the
+// message text. names maps user IDs to names for --user.
+func (opts *filterOptions) compile(text *regexp.Regexp, names map[string]string) (*filter, error) {
+	f := &filter{text: text, names: names, hasFiles: opts.hasFiles}
+
+	var err error
+	if f.oldest, err = timeparse.Bound(opts.since, "--since"); err != nil {
+		return nil, err
+	}
+	if f.latest, err = timeparse.Bound(opts.until, "--until"); err != nil {
+		return nil, err
+	}
+
+	if len(opts.users) > 0 {
+		f.users = make(map[string]bool)
+		for _, u := range opts.users {
+			f.users[strings.ToLower(strings.TrimPrefix(u, "@"))] = true
+		}
+	}
+
+	for _, w := range opts.where {
+		field, pattern, ok := strings.Cut(w, "=")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid --where %q: expected field=regex", w)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --where %q: %w", w, err)
+		}
+		f.where = append(f.where, whereClause{field: field, re: re})
+	}
+	return f, nil
+}
+
+// match reports whether m passes every filter
+func (f *filter) match(m client.Message) bool {
+	if f.oldest != "" && client.CompareTimestamps(m.TS, f.oldest) <= 0 {
+		return false
+	}
+	if f.latest != "" && client.CompareTimestamps(m.TS, f.latest) >= 0 {
+		return false
+	}
+	if f.hasFiles && len(m.Files) == 0 {
+		return false
+	}
+	if f.users != nil && !f.users[strings.ToLower(m.User)] && !f.users[strings.ToLower(f.names[m.User])] &&
+		!(m.User == "" && m.Username != "" && f.users[strings.ToLower(m.Username)]) {
+		return false
+	}
+	if f.text != nil && !f.text.MatchString(m.Text) {
+		return false
+	}
+	if len(f.where) > 0 {
+		fields := messageFields(m)
+		for _, w := range f.where {
+			value, ok := fields[w.field]
+			if !ok || !w.re.MatchString(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// messageFields returns a message's top-level JSON fields as text. Strings
+// are unquoted; other values are left as JSON.
+func messageFields(m client.Message) map[string]string {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(data, &raw) != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(raw))
+	for k, v := range raw {
+		var s string
+		if json.Unmarshal(v, &s) == nil {
+			fields[k] = s
+		} else {
+			fields[k] = string(v)
+		}
+	}
+	return fields
+}
+
+// compilePattern compiles a text regex, case-insensitively if asked
+func compilePattern(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// view has what's needed to show mirrored messages offline
+type view struct {
+	store    *mirror.Store
+	users    *client.UserResolver
+	names    map[string]string
+	renderer *mrkdwn.Renderer
+}
+
+// openView opens the mirror in dir (or the default location) and loads its
+// user and channel names
+func openView(dir string) (*view, error) {
+	if dir == "" {
+		dir = mirror.DefaultDir()
+	}
+	store := mirror.Open(dir)
+	names, err := store.Users()
+	if err != nil {
+		return nil, err
+	}
+	channels, err := store.Channels()
+	if err != nil {
+		return nil, err
+	}
+	channelNames := make(map[string]string, len(channels))
+	for _, ch := range channels {
+		channelNames[ch.ID] = ch.Name
+	}
+
+	users := client.NewStaticUserResolver(names)
+	return &view{
+		store: store,
+		users: users,
+		names: names,
+		renderer: &mrkdwn.Renderer{
+			ResolveUser: users.Resolve,
+			ResolveChannel: func(id string) string {
+				if name, ok := channelNames[id]; ok {
+					return name
+				}
+				return id
+			},
+			Color: output.ColorEnabled(),
+		},
+	}, nil
+}
+
+// line formats a message for text output, with an optional prefix before
+// the author
+func (v *view) line(m client.Message, prefix string) string {
+	text := strings.ReplaceAll(v.renderer.Render(m.Text), "\n", "\n    ")
+	if m.Edited != nil {
+		text += " (edited)"
+	}
+	for _, f := range m.Files {
+		name := f.Title
+		if name == "" {
+			name = f.Name
+		}
+		text += "\n  [file] " + name
+	}
+	return fmt.Sprintf("[%s] %s%s: %s", client.FormatTimestamp(m.TS), prefix, v.users.Author(m), text)
+}
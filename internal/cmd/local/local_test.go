@@ -0,0 +1,176 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// seedMirror writes #incidents and #general to a temporary mirror
+func seedMirror(t *testing.T) string {
+	dir := t.TempDir()
+	store := mirror.Open(dir)
+	require.NoError(t, store.SaveUsers(map[string]string{"U001": "alice", "U002": "bob"}))
+	require.NoError(t, store.Save(&mirror.Channel{ID: "C123", Name: "incidents"}, []client.Message{
+		{TS: "1700000000.000100", User: "U001", Text: "Site is down, timeouts everywhere", ThreadTS: "1700000000.000100", ReplyCount: 2},
+		{TS: "1700000300.000100", User: "U002", Text: "Looking", ThreadTS: "1700000000.000100"},
+		{TS: "1700000400.000100", User: "U001", Text: "Rolled back", ThreadTS: "1700000000.000100",
+			Files: []client.File{{ID: "F1", Name: "graph.png"}}},
+		{TS: "1700000600.000100", User: "U002", Text: "All clear"},
+		{TS: "1700000700.000100", Username: "deploybot", Subtype: "bot_message", Text: "Deploy finished"},
+	}))
+	require.NoError(t, store.Save(&mirror.Channel{ID: "C456", Name: "general"}, []client.Message{
+		{TS: "1700000500.000100", User: "U002", Text: "Any TIMEOUTS in general?"},
+	}))
+	return dir
+}
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	t.Cleanup(func() { output.Writer = os.Stdout })
+	return &buf
+}
+
+func TestFilter(t *testing.T) {
+	names := map[string]string{"U001": "alice"}
+	alice := client.Message{TS: "1700000000.000100", User: "U001", Text: "hello"}
+	bot := client.Message{TS: "1700000700.000100", Username: "deploybot", Subtype: "bot_message", Text: "deployed",
+		Files: []client.File{{ID: "F1"}}}
+
+	tests := []struct {
+		name  string
+		opts  filterOptions
+		match []bool // alice, bot
+	}{
+		{"no filters", filterOptions{}, []bool{true, true}},
+		{"user by name", filterOptions{users: []string{"@Alice"}}, []bool{true, false}},
+		{"user by ID", filterOptions{users: []string{"U001"}}, []bool{true, false}},
+		{"bot username", filterOptions{users: []string{"deploybot"}}, []bool{false, true}},
+		{"since", filterOptions{since: "1700000500.000000"}, []bool{false, true}},
+		{"until", filterOptions{until: "1700000500.000000"}, []bool{true, false}},
+		{"where", filterOptions{where: []string{"subtype=^bot_"}}, []bool{false, true}},
+		{"where missing field", filterOptions{where: []string{"reply_count=."}}, []bool{false, false}},
+		{"has files", filterOptions{hasFiles: true}, []bool{false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.opts.compile(nil, names)
+			require.NoError(t, err)
+			assert.Equal(t, tt.match[0], f.match(alice), "alice")
+			assert.Equal(t, tt.match[1], f.match(bot), "bot")
+		})
+	}
+}
+
+func TestFilter_Invalid(t *testing.T) {
+	_, err := (&filterOptions{where: []string{"subtype"}}).compile(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected field=regex")
+
+	_, err = (&filterOptions{where: []string{"text=("}}).compile(nil, nil)
+	require.Error(t, err)
+
+	_, err = (&filterOptions{since: "someday"}).compile(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--since")
+}
+
+func TestRunChannels(t *testing.T) {
+	buf := captureOutput(t)
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = output.FormatText }()
+
+	require.NoError(t, runChannels(&channelsOptions{dir: seedMirror(t)}))
+
+	var channels []mirror.Channel
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &channels))
+	require.Len(t, channels, 2)
+	assert.Equal(t, "general", channels[0].Name)
+	assert.Equal(t, 3, channels[1].Messages)
+	assert.Equal(t, 2, channels[1].Replies)
+}
+
+func TestRunHistory(t *testing.T) {
+	buf := captureOutput(t)
+	dir := seedMirror(t)
+
+	require.NoError(t, runHistory("#incidents", &historyOptions{dir: dir, limit: 2}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2, "replies aren't listed and the limit applies")
+	assert.Contains(t, lines[0], "deploybot: Deploy finished")
+	assert.Contains(t, lines[1], "bob: All clear")
+
+	buf.Reset()
+	require.NoError(t, runHistory("C123", &historyOptions{dir: dir, match: "SITE", ignoreCase: true}))
+	assert.Contains(t, buf.String(), "alice: Site is down")
+	assert.Contains(t, buf.String(), "[2 replies]")
+	assert.NotContains(t, buf.String(), "All clear")
+
+	err := runHistory("random", &historyOptions{dir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slck sync random")
+}
+
+func TestRunSearch(t *testing.T) {
+	buf := captureOutput(t)
+	dir := seedMirror(t)
+
+	require.NoError(t, runSearch("timeouts", &searchOptions{dir: dir, ignoreCase: true}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "#general bob: Any TIMEOUTS", "newest first across channels")
+	assert.Contains(t, lines[1], "#incidents alice: Site is down")
+
+	buf.Reset()
+	require.NoError(t, runSearch("timeouts", &searchOptions{dir: dir}))
+	assert.NotContains(t, buf.String(), "#general", "case-sensitive by default")
+
+	// Replies are searched too, and filters apply
+	buf.Reset()
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = output.FormatText }()
+	require.NoError(t, runSearch(".", &searchOptions{dir: dir, channels: []string{"incidents"}, filters: filterOptions{hasFiles: true}}))
+
+	var matches []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &matches))
+	require.Len(t, matches, 1)
+	assert.Equal(t, "Rolled back", matches[0]["text"])
+	assert.Equal(t, "C123", matches[0]["channel"])
+	assert.Equal(t, "incidents", matches[0]["channel_name"])
+
+	err := runSearch("(", &searchOptions{dir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid pattern")
+}
+
+func TestRunThread(t *testing.T) {
+	buf := captureOutput(t)
+	dir := seedMirror(t)
+
+	require.NoError(t, runThread("incidents", "1700000000.000100", &threadOptions{dir: dir}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "alice: Site is down")
+	assert.Contains(t, lines[1], "bob: Looking")
+	assert.Contains(t, lines[3], "[file] graph.png")
+
+	buf.Reset()
+	require.NoError(t, runThread("C123", "p1700000000000100", &threadOptions{dir: dir, format: "md"}))
+	assert.True(t, strings.HasPrefix(buf.String(), "# Thread in #incidents\n"), buf.String())
+	assert.Contains(t, buf.String(), "> Looking")
+
+	err := runThread("incidents", "1700009999.000100", &threadOptions{dir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
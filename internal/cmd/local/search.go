@@ -0,0 +1,127 @@
+package local
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type searchOptions struct {
+	dir        string
+	channels   []string
+	limit      int
+	ignoreCase bool
+	filters    filterOptions
+}
+
+// searchMatch is a matching message and the channel it's in
+type searchMatch struct {
+	Channel     string
+	ChannelName string
+	client.Message
+}
+
+// MarshalJSON flattens the message's fields alongside channel and channel_name
+func (m searchMatch) MarshalJSON() ([]byte, error) {
+	return client.MarshalWithFields(m.Message, map[string]interface{}{
+		"channel":      m.Channel,
+		"channel_name": m.ChannelName,
+	})
+}
+
+func newSearchCmd() *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search <regex>",
+		Short: "Search mirrored messages with a regular expression",
+		Long: `Search the text of mirrored messages and thread replies with a regular
+expression (Go RE2 syntax), newest first.
+
+Every mirrored channel is searched unless --channel is given.
+
+Examples:
+  slck local search "timeout|deadline exceeded"
+  slck local search "deploy(ed)? to prod" -i --channel releases
+  slck local search . --where subtype=bot_message --since "7 days ago"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(args[0], opts)
+		},
+	}
+
+	addDirFlag(cmd, &opts.dir)
+	cmd.Flags().StringArrayVar(&opts.channels, "channel", nil, "Only search this channel (repeatable)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 50, "Maximum matches to show (0 for all)")
+	cmd.Flags().BoolVarP(&opts.ignoreCase, "ignore-case", "i", false, "Match case-insensitively")
+	addFilterFlags(cmd, &opts.filters)
+
+	return cmd
+}
+
+func runSearch(pattern string, opts *searchOptions) error {
+	text, err := compilePattern(pattern, opts.ignoreCase)
+	if err != nil {
+		return err
+	}
+	v, err := openView(opts.dir)
+	if err != nil {
+		return err
+	}
+	f, err := opts.filters.compile(text, v.names)
+	if err != nil {
+		return err
+	}
+
+	var channels []mirror.Channel
+	if len(opts.channels) > 0 {
+		for _, name := range opts.channels {
+			ch, err := v.store.FindChannel(name)
+			if err != nil {
+				return err
+			}
+			channels = append(channels, *ch)
+		}
+	} else {
+		if channels, err = v.store.Channels(); err != nil {
+			return err
+		}
+	}
+
+	matches := make([]searchMatch, 0)
+	for _, ch := range channels {
+		messages, err := v.store.Messages(ch.ID)
+		if err != nil {
+			return err
+		}
+		for _, m := range messages {
+			if f.match(m) {
+				matches = append(matches, searchMatch{Channel: ch.ID, ChannelName: ch.Name, Message: m})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return client.CompareTimestamps(matches[i].TS, matches[j].TS) > 0
+	})
+	if opts.limit > 0 && len(matches) > opts.limit {
+		matches = matches[:opts.limit]
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(matches)
+	}
+
+	if len(matches) == 0 {
+		output.Println("No messages found")
+		return nil
+	}
+
+	for _, m := range matches {
+		output.Println(v.line(m.Message, "#"+m.ChannelName+" "))
+	}
+	return nil
+}
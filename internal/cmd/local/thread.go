@@ -0,0 +1,100 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/transcript"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type threadOptions struct {
+	dir    string
+	format string
+}
+
+func newThreadCmd() *cobra.Command {
+	opts := &threadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "thread <channel> <thread-ts> | thread <permalink>",
+		Short: "Show a mirrored thread",
+		Long: `Show a thread from the local mirror: the parent message and its replies.
+
+The thread can be given as a channel and the parent's timestamp, or as a
+permalink to the parent or any reply. With --format md it's printed as a
+Markdown document.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the thread", rest[0])
+			}
+			threadTS := ref.TS
+			if ref.ThreadTS != "" {
+				threadTS = ref.ThreadTS
+			}
+			return runThread(ref.Channel, threadTS, opts)
+		},
+	}
+
+	addDirFlag(cmd, &opts.dir)
+	cmd.Flags().StringVar(&opts.format, "format", "text", "Text format: text, or md for a Markdown document")
+
+	return cmd
+}
+
+func runThread(channel, threadTS string, opts *threadOptions) error {
+	if opts.format != "" && opts.format != "text" && opts.format != "md" {
+		return fmt.Errorf("invalid format %q: must be text or md", opts.format)
+	}
+	threadTS = validate.NormalizeTimestamp(threadTS)
+
+	v, err := openView(opts.dir)
+	if err != nil {
+		return err
+	}
+	ch, err := v.store.FindChannel(channel)
+	if err != nil {
+		return err
+	}
+	all, err := v.store.Messages(ch.ID)
+	if err != nil {
+		return err
+	}
+
+	var messages []client.Message
+	for _, m := range all {
+		if m.TS == threadTS || m.ThreadTS == threadTS {
+			messages = append(messages, m)
+		}
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("message %s not found in the mirror of #%s", threadTS, ch.Name)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(messages)
+	}
+
+	if opts.format == "md" {
+		t := &transcript.Transcript{
+			Title:    "Thread in #" + ch.Name,
+			Threads:  []transcript.Thread{transcript.NewThread(messages)},
+			Users:    v.users,
+			Renderer: v.renderer,
+		}
+		return t.WriteMarkdown(output.Writer)
+	}
+
+	for _, m := range messages {
+		output.Println(v.line(m, ""))
+	}
+	return nil
+}
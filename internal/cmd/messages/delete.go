@@ -23,11 +23,18 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <channel> <timestamp>",
+		Use:   "delete <channel> <timestamp> | delete <permalink>",
 		Short: "Delete a message",
-		Args:  cobra.ExactArgs(2),
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(args[0], args[1], opts, nil)
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the message", rest[0])
+			}
+			return runDelete(ref.Channel, ref.TS, opts, nil)
 		},
 	}
 
@@ -41,6 +48,7 @@ func runDelete(channel, timestamp string, opts *deleteOptions, c *client.Client)
 	if err := validate.Timestamp(timestamp); err != nil {
 		return err
 	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
 
 	// Prompt for confirmation unless --force
 	if !opts.force {
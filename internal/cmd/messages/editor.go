@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// editorCommand returns the user's preferred editor: $VISUAL, then $EDITOR,
+// then a platform default
+func editorCommand() string {
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// launchEditor opens path in the user's editor and waits for it to exit.
+// The editor runs through the shell so values like "code --wait" work.
+func launchEditor(path string) error {
+	editor := editorCommand()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", editor+` "`+path+`"`)
+	} else {
+		cmd = exec.Command("sh", "-c", editor+` "$1"`, "slck-editor", path)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// composeInEditor writes initial to a temp file, opens it in the editor and
+// returns the saved text. Empty or unchanged text aborts with an error.
+func composeInEditor(initial string, editor func(path string) error) (string, error) {
+	if editor == nil {
+		editor = launchEditor
+	}
+
+	f, err := os.CreateTemp("", "slck-message-*.md")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	if _, err := f.WriteString(initial); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := editor(path); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading edited message: %w", err)
+	}
+
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", fmt.Errorf("aborting: message is empty")
+	}
+	if text == strings.TrimSpace(initial) {
+		return "", fmt.Errorf("aborting: message is unchanged")
+	}
+	return text, nil
+}
@@ -0,0 +1,103 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type getOptions struct {
+	raw bool
+}
+
+func newGetCmd() *cobra.Command {
+	opts := &getOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "get <permalink> | get <channel> <timestamp>",
+		Short: "Show a single message",
+		Long: `Show a single message, given as a permalink or as a channel and timestamp.
+
+Permalinks to thread replies (those with a thread_ts parameter) show the reply
+they point to.
+
+Examples:
+  slck messages get https://workspace.slack.com/archives/C1234567890/p1234567890123456
+  slck messages get deploys 1234567890.123456 -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the message", rest[0])
+			}
+			return runGet(ref, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+
+	return cmd
+}
+
+func runGet(ref validate.MessageRef, opts *getOptions, c *client.Client) error {
+	if err := validate.Timestamp(ref.TS); err != nil {
+		return err
+	}
+	ts := validate.NormalizeTimestamp(ref.TS)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(ref.Channel)
+	if err != nil {
+		return err
+	}
+
+	var msg *client.Message
+	if ref.ThreadTS != "" {
+		msg, err = c.GetReply(channelID, ref.ThreadTS, ts)
+	} else {
+		msg, err = c.GetMessage(channelID, ts)
+	}
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get message %s", ts), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(msg)
+	}
+
+	resolver := client.NewUserResolver(c)
+	renderer := mrkdwn.NewRenderer(c, resolver)
+	output.Printf("[%s] %s: %s\n", formatTimestamp(msg.TS), resolver.Author(*msg), messageBody(renderer, *msg, opts.raw))
+	if msg.ThreadTS != "" && msg.ThreadTS != msg.TS {
+		output.Printf("  in thread %s\n", msg.ThreadTS)
+	}
+	if msg.ReplyCount > 0 {
+		output.Printf("  %d replies (latest %s)\n", msg.ReplyCount, formatTimestamp(msg.LatestReply))
+	}
+	for _, r := range msg.Reactions {
+		output.Printf("  :%s: %d\n", r.Name, r.Count)
+	}
+	if msg.Metadata != nil {
+		payload, _ := json.Marshal(msg.Metadata.EventPayload)
+		output.Printf("  metadata: %s %s\n", msg.Metadata.EventType, payload)
+	}
+
+	return nil
+}
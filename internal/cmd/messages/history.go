@@ -1,16 +1,22 @@
 package messages
 
 import (
+	"encoding/json"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
 )
 
 type historyOptions struct {
-	limit  int
-	oldest string
-	latest string
+	limit     int
+	oldest    string
+	latest    string
+	raw       bool
+	permalink bool
 }
 
 func newHistoryCmd() *cobra.Command {
@@ -19,22 +25,41 @@ func newHistoryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "history <channel>",
 		Short: "Get channel message history",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get channel message history, newest first.
+
+--oldest and --latest accept a Slack timestamp or any of these time formats:
+` + timeparse.Syntax + `
+
+Examples:
+  slck messages history general --limit 50
+  slck messages history general --oldest "2 days ago"
+  slck messages history general --oldest 2025-06-01 --latest 2025-06-02`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runHistory(args[0], opts, nil)
 		},
 	}
 
 	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum messages to return")
-	cmd.Flags().StringVar(&opts.oldest, "oldest", "", "Only messages after this timestamp")
-	cmd.Flags().StringVar(&opts.latest, "latest", "", "Only messages before this timestamp")
+	cmd.Flags().StringVar(&opts.oldest, "oldest", "", "Only messages after this timestamp or time")
+	cmd.Flags().StringVar(&opts.latest, "latest", "", "Only messages before this timestamp or time")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "Include each message's permalink (one API call per uncached message)")
 
 	return cmd
 }
 
 func runHistory(channel string, opts *historyOptions, c *client.Client) error {
+	oldest, err := timeparse.Bound(opts.oldest, "--oldest")
+	if err != nil {
+		return err
+	}
+	latest, err := timeparse.Bound(opts.latest, "--latest")
+	if err != nil {
+		return err
+	}
+
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
@@ -47,11 +72,17 @@ func runHistory(channel string, opts *historyOptions, c *client.Client) error {
 		return err
 	}
 
-	messages, err := c.GetChannelHistory(channelID, opts.limit, opts.oldest, opts.latest)
+	messages, err := c.GetChannelHistory(channelID, opts.limit, oldest, latest)
 	if err != nil {
 		return err
 	}
 
+	if opts.permalink {
+		if err := addPermalinks(c, channelID, messages); err != nil {
+			return err
+		}
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(messages)
 	}
@@ -62,11 +93,19 @@ func runHistory(channel string, opts *historyOptions, c *client.Client) error {
 	}
 
 	resolver := client.NewUserResolver(c)
+	renderer := mrkdwn.NewRenderer(c, resolver)
 	for _, m := range messages {
 		ts := formatTimestamp(m.TS)
-		text := truncate(resolver.ResolveMentions(m.Text), 80)
-		name := resolver.Resolve(m.User)
+		text := messageBody(renderer, m, opts.raw)
+		name := resolver.Author(m)
 		output.Printf("[%s] %s: %s\n", ts, name, text)
+		if m.Permalink != "" {
+			output.Printf("  %s\n", m.Permalink)
+		}
+		if m.Metadata != nil {
+			payload, _ := json.Marshal(m.Metadata.EventPayload)
+			output.Printf("  metadata: %s %s\n", m.Metadata.EventType, payload)
+		}
 	}
 
 	return nil
@@ -0,0 +1,151 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/filelock"
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+)
+
+const (
+	// defaultIdempotencyTTL is how long an idempotency key is remembered by default
+	defaultIdempotencyTTL = 24 * time.Hour
+
+	// pendingIdempotencyTTL is how long a key is held for a send in progress.
+	// A send that crashed before recording its message holds the key this long.
+	pendingIdempotencyTTL = 2 * time.Minute
+)
+
+// idempotencyEntry records the message sent for an idempotency key. A pending
+// entry holds the key while its message is being sent.
+type idempotencyEntry struct {
+	Channel   string    `json:"channel"`
+	TS        string    `json:"ts"`
+	SentAt    time.Time `json:"sent_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Pending   bool      `json:"pending,omitempty"`
+}
+
+// expired reports whether the entry's key should be forgotten. Entries
+// written before keys had their own expiry use the default TTL.
+func (e idempotencyEntry) expired(now time.Time) bool {
+	expiresAt := e.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = e.SentAt.Add(defaultIdempotencyTTL)
+	}
+	return now.After(expiresAt)
+}
+
+// idempotencyJournal maps idempotency keys to the messages they sent, so
+// retried sends don't post duplicates
+type idempotencyJournal struct {
+	path    string
+	Entries map[string]idempotencyEntry `json:"entries"`
+}
+
+// idempotencyJournalPath returns the journal location under the config directory
+func idempotencyJournalPath() string {
+	return filepath.Join(keychain.ConfigDir(), "idempotency.json")
+}
+
+// loadIdempotencyJournal reads the journal, dropping expired entries.
+// A missing journal is treated as empty.
+func loadIdempotencyJournal(path string, now time.Time) (*idempotencyJournal, error) {
+	j := &idempotencyJournal{path: path, Entries: make(map[string]idempotencyEntry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading idempotency journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("parsing idempotency journal %s: %w", path, err)
+	}
+	if j.Entries == nil {
+		j.Entries = make(map[string]idempotencyEntry)
+	}
+
+	for key, entry := range j.Entries {
+		if entry.expired(now) {
+			delete(j.Entries, key)
+		}
+	}
+	return j, nil
+}
+
+// claim looks up key and, if it isn't taken, holds it with a pending entry
+// for a send about to start. Checking and holding the key under one lock means
+// only one of several sends running in parallel with the same key posts.
+// It returns the existing entry if the key was already taken.
+func (j *idempotencyJournal) claim(key, channel string, now time.Time) (*idempotencyEntry, error) {
+	var existing *idempotencyEntry
+	err := j.update(now, func() {
+		if entry, ok := j.Entries[key]; ok {
+			existing = &entry
+			return
+		}
+		j.Entries[key] = idempotencyEntry{Channel: channel, SentAt: now, ExpiresAt: now.Add(pendingIdempotencyTTL), Pending: true}
+	})
+	return existing, err
+}
+
+// release drops the pending entry for key after its send failed, so the send
+// can be retried straight away
+func (j *idempotencyJournal) release(key string, now time.Time) error {
+	return j.update(now, func() {
+		if j.Entries[key].Pending {
+			delete(j.Entries, key)
+		}
+	})
+}
+
+// record remembers the message sent for key until ttl has passed, and saves
+// the journal
+func (j *idempotencyJournal) record(key, channel, ts string, now time.Time, ttl time.Duration) error {
+	return j.update(now, func() {
+		j.Entries[key] = idempotencyEntry{Channel: channel, TS: ts, SentAt: now, ExpiresAt: now.Add(ttl)}
+	})
+}
+
+// update applies change to the journal and saves it. The journal is read
+// again under a lock first, so keys recorded by sends running in parallel,
+// such as other CI steps, aren't lost.
+func (j *idempotencyJournal) update(now time.Time, change func()) error {
+	unlock, err := filelock.Lock(j.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	latest, err := loadIdempotencyJournal(j.path, now)
+	if err != nil {
+		return err
+	}
+	j.Entries = latest.Entries
+	change()
+	return j.save()
+}
+
+// save writes the journal atomically
+func (j *idempotencyJournal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
@@ -0,0 +1,43 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// How --resolve-mentions handles mentions that match nobody
+const (
+	unresolvedFail = "fail"
+	unresolvedWarn = "warn"
+)
+
+// validateUnresolvedMode checks the --unresolved flag value
+func validateUnresolvedMode(mode string) error {
+	if mode != unresolvedFail && mode != unresolvedWarn {
+		return fmt.Errorf("invalid --unresolved value %q: must be one of: %s, %s", mode, unresolvedFail, unresolvedWarn)
+	}
+	return nil
+}
+
+// encodeMentions rewrites @user, @group, #channel and email mentions in text
+// into Slack mention syntax. Mentions that can't be resolved are an error,
+// or a warning on stderr when mode is "warn".
+func encodeMentions(c *client.Client, text, mode string) (string, error) {
+	encoded, unresolved, err := client.NewMentionEncoder(c).Encode(text)
+	if err != nil {
+		return "", err
+	}
+	if len(unresolved) == 0 {
+		return encoded, nil
+	}
+
+	list := strings.Join(unresolved, ", ")
+	if mode == unresolvedWarn {
+		fmt.Fprintf(os.Stderr, "Warning: could not resolve %s; sending as plain text\n", list)
+		return encoded, nil
+	}
+	return "", fmt.Errorf("could not resolve %s (use --unresolved=warn to send anyway)", list)
+}
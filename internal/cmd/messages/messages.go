@@ -1,11 +1,15 @@
 package messages
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
 )
 
 // NewCmd creates the messages command with all subcommands
@@ -19,10 +23,16 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newSendCmd())
 	cmd.AddCommand(newUpdateCmd())
 	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newPurgeCmd())
+	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newPermalinkCmd())
 	cmd.AddCommand(newHistoryCmd())
 	cmd.AddCommand(newThreadCmd())
 	cmd.AddCommand(newReactCmd())
 	cmd.AddCommand(newUnreactCmd())
+	cmd.AddCommand(newReactionsCmd())
+	cmd.AddCommand(newTailCmd())
+	cmd.AddCommand(newStreamCmd())
 
 	return cmd
 }
@@ -43,6 +53,105 @@ func formatTimestamp(ts string) string {
 	return t.Format("2006-01-02 15:04")
 }
 
+// messageText returns a message's text for terminal output: rendered from
+// mrkdwn, or exactly as Slack sent it with --raw. Continuation lines are
+// indented so multi-line messages stay readable.
+func messageText(r *mrkdwn.Renderer, text string, raw bool) string {
+	if !raw {
+		text = r.Render(text)
+	}
+	return strings.ReplaceAll(text, "\n", "\n    ")
+}
+
+// messageBody returns a message's text for terminal output, followed by an
+// edit marker and one indented line per attachment and shared file
+func messageBody(r *mrkdwn.Renderer, m client.Message, raw bool) string {
+	var b strings.Builder
+	b.WriteString(messageText(r, m.Text, raw))
+	if m.Edited != nil {
+		b.WriteString(" (edited)")
+	}
+
+	for _, a := range m.Attachments {
+		if line := attachmentLine(r, a, raw); line != "" {
+			b.WriteString("\n  [attachment] " + line)
+		}
+	}
+	for _, f := range m.Files {
+		b.WriteString("\n  [file] " + fileLine(f))
+	}
+	return b.String()
+}
+
+// attachmentLine summarizes a legacy attachment on a single line
+func attachmentLine(r *mrkdwn.Renderer, a client.Attachment, raw bool) string {
+	render := func(text string) string {
+		if raw {
+			return text
+		}
+		return r.Render(text)
+	}
+
+	var parts []string
+	if a.Title != "" {
+		parts = append(parts, a.Title)
+	}
+	if a.Text != "" {
+		parts = append(parts, render(a.Text))
+	} else if a.Title == "" && a.Fallback != "" {
+		parts = append(parts, a.Fallback)
+	}
+	for _, f := range a.Fields {
+		parts = append(parts, f.Title+": "+render(f.Value))
+	}
+
+	line := strings.Join(parts, " - ")
+	line = strings.ReplaceAll(line, "\n", " ")
+	if a.TitleLink != "" {
+		line += " (" + a.TitleLink + ")"
+	}
+	return line
+}
+
+// fileLine summarizes a shared file: its name, type, size and permalink
+func fileLine(f client.File) string {
+	name := f.Title
+	if name == "" {
+		name = f.Name
+	}
+
+	var details []string
+	if f.Filetype != "" {
+		details = append(details, f.Filetype)
+	}
+	if f.Size > 0 {
+		details = append(details, formatSize(f.Size))
+	}
+
+	line := name
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	if f.Permalink != "" {
+		line += " " + f.Permalink
+	}
+	return line
+}
+
+// formatSize formats a byte count for display
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // truncate shortens a string to maxLen, replacing newlines with spaces
 func truncate(s string, maxLen int) string {
 	// Replace newlines with spaces
@@ -62,17 +171,3 @@ func unescapeShellChars(s string) string {
 	s = strings.ReplaceAll(s, `\!`, `!`)
 	return s
 }
-
-// buildDefaultBlocks creates a Block Kit section block with mrkdwn formatting.
-// This provides a more refined appearance compared to plain text messages.
-func buildDefaultBlocks(text string) []interface{} {
-	return []interface{}{
-		map[string]interface{}{
-			"type": "section",
-			"text": map[string]interface{}{
-				"type": "mrkdwn",
-				"text": text,
-			},
-		},
-	}
-}
@@ -1,17 +1,30 @@
 package messages
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
 
 func TestFormatTimestamp(t *testing.T) {
@@ -179,62 +192,6 @@ func TestUnescapeShellChars(t *testing.T) {
 	}
 }
 
-func TestBuildDefaultBlocks(t *testing.T) {
-	tests := []struct {
-		name string
-		text string
-	}{
-		{
-			name: "simple text",
-			text: "Hello World",
-		},
-		{
-			name: "markdown text",
-			text: "*bold* _italic_ ~strike~",
-		},
-		{
-			name: "empty text",
-			text: "",
-		},
-		{
-			name: "text with special characters",
-			text: "Hello <@U123> in #general",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := buildDefaultBlocks(tt.text)
-
-			if len(result) != 1 {
-				t.Fatalf("expected 1 block, got %d", len(result))
-			}
-
-			block, ok := result[0].(map[string]interface{})
-			if !ok {
-				t.Fatal("expected block to be map[string]interface{}")
-			}
-
-			if block["type"] != "section" {
-				t.Errorf("expected block type 'section', got %v", block["type"])
-			}
-
-			textObj, ok := block["text"].(map[string]interface{})
-			if !ok {
-				t.Fatal("expected text to be map[string]interface{}")
-			}
-
-			if textObj["type"] != "mrkdwn" {
-				t.Errorf("expected text type 'mrkdwn', got %v", textObj["type"])
-			}
-
-			if textObj["text"] != tt.text {
-				t.Errorf("expected text %q, got %v", tt.text, textObj["text"])
-			}
-		})
-	}
-}
-
 // Command handler tests
 
 func TestRunSend_Success(t *testing.T) {
@@ -483,6 +440,50 @@ func TestRunThread_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunThread_Markdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.replies":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.123456", "user": "U001", "text": "Original *bold*"},
+					{"ts": "1234567890.123457", "user": "U002", "text": "Reply 1"},
+				},
+			})
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "incidents"},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runThread("C123", "1234567890.123456", &threadOptions{limit: 100, format: "md"}, c)
+	require.NoError(t, err)
+
+	got := buf.String()
+	assert.True(t, strings.HasPrefix(got, "# Thread in #incidents\n"))
+	assert.Contains(t, got, "**alice** · ")
+	assert.Contains(t, got, "Original **bold**")
+	assert.Contains(t, got, "> **bob** · ")
+	assert.Contains(t, got, "> Reply 1")
+}
+
+func TestRunThread_InvalidFormat(t *testing.T) {
+	err := runThread("C123", "1234567890.123456", &threadOptions{limit: 100, format: "html"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be text or md")
+}
+
 func TestRunReact_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/reactions.add", r.URL.Path)
@@ -1276,3 +1277,1636 @@ func TestRunSend_FileUpload_NonexistentFile(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot access file")
 }
+
+// Long message handling tests
+
+func TestSplitText(t *testing.T) {
+	t.Run("short text is not split", func(t *testing.T) {
+		chunks := splitText("Hello World", 100)
+		assert.Equal(t, []string{"Hello World"}, chunks)
+	})
+
+	t.Run("splits on paragraph breaks", func(t *testing.T) {
+		text := strings.Repeat("a", 30) + "\n\n" + strings.Repeat("b", 30)
+		chunks := splitText(text, 40)
+		assert.Equal(t, []string{strings.Repeat("a", 30), strings.Repeat("b", 30)}, chunks)
+	})
+
+	t.Run("splits on line breaks", func(t *testing.T) {
+		text := "line one is here\nline two is here\nline three is here"
+		chunks := splitText(text, 40)
+		require.Len(t, chunks, 2)
+		assert.Equal(t, "line one is here\nline two is here", chunks[0])
+		assert.Equal(t, "line three is here", chunks[1])
+	})
+
+	t.Run("hard splits text without boundaries", func(t *testing.T) {
+		text := strings.Repeat("x", 100)
+		chunks := splitText(text, 40)
+		for _, chunk := range chunks {
+			assert.LessOrEqual(t, len(chunk), 40)
+		}
+		assert.Equal(t, text, strings.Join(chunks, ""))
+	})
+
+	t.Run("does not break multibyte characters", func(t *testing.T) {
+		text := strings.Repeat("👋", 50)
+		chunks := splitText(text, 20)
+		for _, chunk := range chunks {
+			assert.True(t, utf8.ValidString(chunk))
+			assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 20)
+		}
+		assert.Equal(t, text, strings.Join(chunks, ""))
+	})
+
+	t.Run("keeps code fences balanced", func(t *testing.T) {
+		var lines []string
+		for i := 0; i < 20; i++ {
+			lines = append(lines, fmt.Sprintf("log line %02d", i))
+		}
+		text := "Build output:\n```\n" + strings.Join(lines, "\n") + "\n```"
+		chunks := splitText(text, 80)
+		require.Greater(t, len(chunks), 1)
+		for i, chunk := range chunks {
+			assert.LessOrEqual(t, len(chunk), 80, "chunk %d too long", i)
+			assert.Equal(t, 0, strings.Count(chunk, "```")%2, "chunk %d has unbalanced fences: %q", i, chunk)
+		}
+		assert.True(t, strings.HasPrefix(chunks[1], "```\n"))
+	})
+}
+
+func TestRunSend_Split(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"ts": fmt.Sprintf("1234567890.00000%d", len(requests)),
+		})
+	}))
+	defer server.Close()
+
+	paragraph := strings.Repeat("word ", 500)
+	text := paragraph + "\n\n" + paragraph + "\n\n" + paragraph
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{split: true}
+
+	err := runSend("C123", text, opts, c)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+	assert.Nil(t, requests[0]["thread_ts"])
+	assert.Equal(t, "1234567890.000001", requests[1]["thread_ts"])
+	assert.Equal(t, "1234567890.000001", requests[2]["thread_ts"])
+	for _, req := range requests {
+		blocks := req["blocks"].([]interface{})
+		section := blocks[0].(map[string]interface{})["text"].(map[string]interface{})
+		assert.LessOrEqual(t, len(section["text"].(string)), sectionTextLimit)
+	}
+}
+
+func TestRunSend_SplitShortTextSendsOnce(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"ts": "1234567890.123456",
+		})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{split: true}
+
+	err := runSend("C123", "Short message", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRunSend_OverflowFile(t *testing.T) {
+	var uploaded []byte
+	var completeBody map[string]interface{}
+
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer uploadServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files.getUploadURLExternal":
+			assert.Equal(t, "message.txt", r.URL.Query().Get("filename"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"upload_url": uploadServer.URL + "/upload",
+				"file_id":    "F123",
+			})
+		case "/files.completeUploadExternal":
+			_ = json.NewDecoder(r.Body).Decode(&completeBody)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	text := strings.Repeat("x", sectionTextLimit+1)
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{overflow: "file"}
+
+	err := runSend("C123", text, opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(uploaded))
+	assert.Equal(t, "C123", completeBody["channel_id"])
+}
+
+func TestRunSend_OverflowValidation(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+
+	err := runSend("C123", "Hello", &sendOptions{overflow: "truncate"}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid overflow mode")
+
+	err = runSend("C123", "Hello", &sendOptions{split: true, overflow: "file"}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--split cannot be used")
+
+	err = runSend("C123", "Hello", &sendOptions{split: true, blocksJSON: "[]"}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used with blocks")
+}
+
+func TestRunSend_MsgTooLongSuggestsSplit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "msg_too_long"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runSend("C123", "Hello", &sendOptions{simple: true}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Maximum is 40,000 characters")
+	assert.Contains(t, err.Error(), "Use --split")
+
+	// Other commands don't have --split, so only get the generic hint
+	err = client.WrapError("update message", fmt.Errorf("slack API error: msg_too_long"))
+	assert.Contains(t, err.Error(), "Maximum is 40,000 characters")
+	assert.NotContains(t, err.Error(), "--split")
+}
+
+// Tail tests
+
+func TestRunTail_PrintsNewMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			polls++
+			assert.NotEmpty(t, r.URL.Query().Get("oldest"))
+			messages := []map[string]interface{}{}
+			if polls == 1 {
+				// Slack returns newest first
+				messages = []map[string]interface{}{
+					{"ts": "1234567890.000002", "user": "U002", "text": "second"},
+					{"ts": "1234567890.000001", "user": "U001", "text": "first"},
+				}
+			} else {
+				assert.Equal(t, "1234567890.000002", r.URL.Query().Get("oldest"))
+				cancel()
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"messages": messages,
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{interval: time.Millisecond, maxInterval: time.Millisecond}
+
+	err := runTail(ctx, []string{"C123"}, opts, c)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "alice: first")
+	assert.Contains(t, lines[1], "bob: second")
+}
+
+func TestRunTail_FollowsCursor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			resp := map[string]interface{}{"ok": true, "messages": []map[string]interface{}{}}
+			switch {
+			case r.URL.Query().Get("cursor") == "page2":
+				// The older of the messages that arrived since the last poll
+				resp["messages"] = []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "first"},
+				}
+			case polls == 0:
+				// A full page of newer messages, newest first
+				polls++
+				var page []map[string]interface{}
+				for i := 201; i >= 2; i-- {
+					page = append(page, map[string]interface{}{"ts": fmt.Sprintf("1234567890.%06d", i), "user": "U002", "text": "later"})
+				}
+				resp["messages"] = page
+				resp["response_metadata"] = map[string]interface{}{"next_cursor": "page2"}
+			default:
+				cancel()
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{interval: time.Millisecond, maxInterval: time.Millisecond}
+	require.NoError(t, runTail(ctx, []string{"C123"}, opts, c))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 201, "messages on the next page aren't skipped")
+	assert.Contains(t, lines[0], "alice: first")
+	assert.Contains(t, lines[200], "bob: later")
+}
+
+func TestRunTail_JSONLines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			polls++
+			messages := []map[string]interface{}{}
+			if polls == 1 {
+				messages = []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "hello"},
+				}
+			} else {
+				cancel()
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"messages": messages,
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{interval: time.Millisecond, maxInterval: time.Millisecond}
+
+	err := runTail(ctx, []string{"C123"}, opts, c)
+	require.NoError(t, err)
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &event))
+	assert.Equal(t, "C123", event["channel"])
+	assert.Equal(t, "alice", event["user_name"])
+	assert.Equal(t, "hello", event["text"])
+}
+
+func TestRunTail_Replies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			polls++
+			messages := []map[string]interface{}{}
+			if polls == 1 {
+				messages = []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "deploying"},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"messages": messages,
+			})
+		case "/conversations.replies":
+			assert.Equal(t, "1234567890.000001", r.URL.Query().Get("ts"))
+			messages := []map[string]interface{}{
+				{"ts": "1234567890.000001", "user": "U001", "text": "deploying"},
+			}
+			if polls == 2 {
+				messages = append(messages, map[string]interface{}{
+					"ts": "1234567890.000005", "user": "U002", "text": "done", "thread_ts": "1234567890.000001",
+				})
+			}
+			if polls >= 3 {
+				cancel()
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"messages": messages,
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{interval: time.Millisecond, maxInterval: time.Millisecond, replies: true}
+
+	err := runTail(ctx, []string{"C123"}, opts, c)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "alice: deploying")
+	assert.Contains(t, lines[1], "↳ bob: done")
+}
+
+func TestRunTail_InvalidInterval(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	err := runTail(context.Background(), []string{"C123"}, &tailOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--interval")
+}
+
+func TestRunReactions_ResolvesUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reactions.get":
+			assert.Equal(t, "C123", r.URL.Query().Get("channel"))
+			assert.Equal(t, "1234567890.123456", r.URL.Query().Get("timestamp"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"message": map[string]interface{}{
+					"ts": "1234567890.123456",
+					"reactions": []map[string]interface{}{
+						{"name": "thumbsup", "count": 2, "users": []string{"U001", "U002"}},
+						{"name": "eyes", "count": 1, "users": []string{"U002"}},
+					},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runReactions("C123", "https://workspace.slack.com/archives/C123/p1234567890123456", &reactionsOptions{}, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), ":thumbsup: 2  alice, bob")
+	assert.Contains(t, buf.String(), ":eyes: 1  bob")
+}
+
+func TestRunReactions_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reactions.get":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"message": map[string]interface{}{
+					"reactions": []map[string]interface{}{
+						{"name": "tada", "count": 1, "users": []string{"U001"}},
+					},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runReactions("C123", "1234567890.123456", &reactionsOptions{}, c)
+	require.NoError(t, err)
+
+	var got []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "tada", got[0]["name"])
+	assert.Equal(t, []interface{}{"alice"}, got[0]["user_names"])
+}
+
+func TestRunStream_FinalizesAndUploads(t *testing.T) {
+	var mu sync.Mutex
+	var posted map[string]interface{}
+	var updates []string
+	var uploaded []byte
+	var completeBody map[string]interface{}
+
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer uploadServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+		case "/chat.update":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "1234567890.123456", body["ts"])
+			updates = append(updates, body["text"].(string))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/files.getUploadURLExternal":
+			assert.Equal(t, "output.log", r.URL.Query().Get("filename"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"upload_url": uploadServer.URL + "/upload",
+				"file_id":    "F123",
+			})
+		case "/files.completeUploadExternal":
+			_ = json.NewDecoder(r.Body).Decode(&completeBody)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	input := "step 1\nstep 2\n```inline fence```\nstep 4\n"
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	// A long interval means only the final update is sent
+	opts := &streamOptions{title: "Build", lines: 2, interval: time.Hour, upload: true, stdin: strings.NewReader(input)}
+
+	err := runStream(context.Background(), "C123", opts, c)
+	require.NoError(t, err)
+
+	assert.Contains(t, posted["text"], "*Build* running")
+	require.Len(t, updates, 1)
+	assert.Contains(t, updates[0], ":white_check_mark: *Build* finished")
+	assert.Contains(t, updates[0], "(4 lines)")
+	assert.Contains(t, updates[0], "'''inline fence'''\nstep 4\n```")
+	assert.NotContains(t, updates[0], "step 2")
+
+	assert.Equal(t, input, string(uploaded))
+	assert.Equal(t, "1234567890.123456", completeBody["thread_ts"])
+	assert.Contains(t, buf.String(), "Streamed 4 lines")
+}
+
+func TestRunStream_DebouncesUpdates(t *testing.T) {
+	var mu sync.Mutex
+	updates := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+		case "/chat.update":
+			updates++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	pr, pw := io.Pipe()
+	go func() {
+		for i := 0; i < 50; i++ {
+			fmt.Fprintf(pw, "line %d\n", i)
+			time.Sleep(time.Millisecond)
+		}
+		_ = pw.Close()
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &streamOptions{title: "Build", lines: 5, interval: 20 * time.Millisecond, stdin: pr}
+
+	err := runStream(context.Background(), "C123", opts, c)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Far fewer updates than lines, but at least the final one
+	assert.GreaterOrEqual(t, updates, 1)
+	assert.Less(t, updates, 25)
+}
+
+func TestStream_KeepsFullOutputOnlyForUpload(t *testing.T) {
+	s := &stream{maxLines: 2}
+	for i := 0; i < 5; i++ {
+		s.add(fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, 5, s.count)
+	assert.Equal(t, []string{"line 3", "line 4"}, s.tail)
+	assert.Zero(t, s.full.Len())
+
+	s = &stream{maxLines: 2, keepFull: true}
+	s.add("line 0")
+	s.add("line 1")
+	assert.Equal(t, "line 0\nline 1\n", s.full.String())
+}
+
+func TestReadLines_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		done <- readLines(ctx, strings.NewReader("one\ntwo\nthree\n"), lines)
+	}()
+
+	assert.Equal(t, "one", <-lines)
+	// Nothing reads the next line, so only the cancel lets readLines return
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("readLines didn't stop after cancel")
+	}
+}
+
+func TestBuildMetadata(t *testing.T) {
+	meta, err := buildMetadata("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+
+	meta, err = buildMetadata("build", `{"id":42}`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "build", meta.EventType)
+	assert.Equal(t, float64(42), meta.EventPayload["id"])
+
+	meta, err = buildMetadata("", "", "build-42")
+	require.NoError(t, err)
+	assert.Equal(t, defaultMetadataType, meta.EventType)
+	assert.Equal(t, "build-42", meta.EventPayload[upsertPayloadKey])
+
+	_, err = buildMetadata("", `{"id":42}`, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--metadata-type is required")
+
+	_, err = buildMetadata("build", `[1,2]`, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "JSON object")
+}
+
+func TestRunSend_WithMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		metadata := body["metadata"].(map[string]interface{})
+		assert.Equal(t, "build", metadata["event_type"])
+		assert.Equal(t, map[string]interface{}{"id": float64(42)}, metadata["event_payload"])
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, metaType: "build", metaJSON: `{"id":42}`}
+
+	err := runSend("C123", "Build started", opts, c)
+	require.NoError(t, err)
+}
+
+func upsertServer(t *testing.T, history []map[string]interface{}, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT", "bot_id": "B123"})
+		case "/conversations.history":
+			assert.Equal(t, "true", r.URL.Query().Get("include_all_metadata"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": history})
+		case "/chat.update":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "1234567890.000002", body["ts"])
+			assert.Equal(t, "Build #42: passing", body["text"])
+			metadata := body["metadata"].(map[string]interface{})
+			assert.Equal(t, "build-42", metadata["event_payload"].(map[string]interface{})[upsertPayloadKey])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/chat.postMessage":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567899.000000"})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestRunSend_UpsertUpdatesExisting(t *testing.T) {
+	keyed := func(ts, user, botID, key string) map[string]interface{} {
+		return map[string]interface{}{
+			"ts": ts, "user": user, "bot_id": botID, "text": "old",
+			"metadata": map[string]interface{}{
+				"event_type":    defaultMetadataType,
+				"event_payload": map[string]interface{}{upsertPayloadKey: key},
+			},
+		}
+	}
+	history := []map[string]interface{}{
+		keyed("1234567890.000003", "U999", "", "build-42"), // someone else's message
+		keyed("1234567890.000002", "", "B123", "build-42"),
+		keyed("1234567890.000001", "UBOT", "B123", "build-41"),
+	}
+
+	var calls []string
+	server := upsertServer(t, history, &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, upsertKey: "build-42"}
+
+	err := runSend("C123", "Build #42: passing", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/auth.test", "/conversations.history", "/chat.update"}, calls)
+	assert.Contains(t, buf.String(), "Message updated (ts: 1234567890.000002)")
+}
+
+func TestRunSend_UpsertPostsWhenMissing(t *testing.T) {
+	var calls []string
+	server := upsertServer(t, []map[string]interface{}{{"ts": "1234567890.000001", "user": "UBOT", "text": "unrelated"}}, &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, upsertKey: "build-42"}
+
+	err := runSend("C123", "Build #42: running", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/auth.test", "/conversations.history", "/chat.postMessage"}, calls)
+	assert.Contains(t, buf.String(), "Message sent (ts: 1234567899.000000)")
+}
+
+func TestRunSend_UpsertRejectsFiles(t *testing.T) {
+	err := runSend("C123", "text", &sendOptions{upsertKey: "k", files: []string{"a.txt"}}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used with files")
+}
+
+func idempotencyServer(t *testing.T, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.000001"})
+		case "/chat.update":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "1234567890.000001", body["ts"])
+			assert.Equal(t, "v1.2 is out (fixed)", body["text"])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestRunSend_IdempotencyKeySkipsRepeat(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var calls []string
+	server := idempotencyServer(t, &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour}
+
+	require.NoError(t, runSend("C123", "v1.2 is out", opts, c))
+	require.NoError(t, runSend("C123", "v1.2 is out", opts, c))
+
+	assert.Equal(t, []string{"/chat.postMessage"}, calls)
+	assert.Contains(t, buf.String(), "Message sent (ts: 1234567890.000001)")
+	assert.Contains(t, buf.String(), "Message already sent (ts: 1234567890.000001)")
+}
+
+func TestRunSend_IdempotencyKeyUpdateExisting(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var calls []string
+	server := idempotencyServer(t, &calls)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runSend("C123", "v1.2 is out", &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour}, c))
+
+	opts := &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour, updateExist: true}
+	require.NoError(t, runSend("C123", "v1.2 is out (fixed)", opts, c))
+
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update"}, calls)
+	assert.Contains(t, buf.String(), "Message updated (ts: 1234567890.000001)")
+}
+
+func TestRunSend_IdempotencyKeyOtherChannel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var calls []string
+	server := idempotencyServer(t, &calls)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour}
+	require.NoError(t, runSend("C123", "v1.2 is out", opts, c))
+
+	err := runSend("C456", "v1.2 is out", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already used for a message in channel C123")
+}
+
+func TestLoadIdempotencyJournal_DropsExpired(t *testing.T) {
+	path := t.TempDir() + "/idempotency.json"
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	j, err := loadIdempotencyJournal(path, now)
+	require.NoError(t, err)
+	assert.Empty(t, j.Entries)
+
+	require.NoError(t, j.record("old", "C123", "1.0", now.Add(-2*time.Hour), time.Hour))
+	require.NoError(t, j.record("new", "C123", "2.0", now.Add(-30*time.Minute), time.Hour))
+	require.NoError(t, j.record("long", "C123", "3.0", now.Add(-2*time.Hour), 7*24*time.Hour))
+
+	j, err = loadIdempotencyJournal(path, now)
+	require.NoError(t, err)
+	assert.NotContains(t, j.Entries, "old")
+	assert.Equal(t, "2.0", j.Entries["new"].TS)
+	assert.Equal(t, "3.0", j.Entries["long"].TS, "each key keeps the TTL it was recorded with")
+}
+
+func TestRunSend_IdempotencyShortTTLKeepsOtherKeys(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var calls []string
+	server := idempotencyServer(t, &calls)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runSend("C123", "weekly", &sendOptions{simple: true, idemKey: "weekly", idemTTL: 7 * 24 * time.Hour}, c))
+	require.NoError(t, runSend("C123", "quick", &sendOptions{simple: true, idemKey: "quick", idemTTL: time.Nanosecond}, c))
+
+	j, err := loadIdempotencyJournal(idempotencyJournalPath(), time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, j.Entries, "weekly", "a short TTL on one send doesn't expire other keys")
+	assert.NotContains(t, j.Entries, "quick")
+}
+
+func TestIdempotencyJournal_ParallelRecords(t *testing.T) {
+	path := t.TempDir() + "/idempotency.json"
+	now := time.Now()
+
+	// Each writer loads the journal before any of them records, as parallel
+	// CI steps would
+	const writers = 8
+	journals := make([]*idempotencyJournal, writers)
+	for i := range journals {
+		j, err := loadIdempotencyJournal(path, now)
+		require.NoError(t, err)
+		journals[i] = j
+	}
+
+	var wg sync.WaitGroup
+	for i, j := range journals {
+		wg.Add(1)
+		go func(i int, j *idempotencyJournal) {
+			defer wg.Done()
+			assert.NoError(t, j.record(fmt.Sprintf("key-%d", i), "C123", fmt.Sprintf("%d.0", i), now, time.Hour))
+		}(i, j)
+	}
+	wg.Wait()
+
+	j, err := loadIdempotencyJournal(path, now)
+	require.NoError(t, err)
+	assert.Len(t, j.Entries, writers, "no send overwrites another's key")
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestRunSend_IdempotencyParallelSendsPostOnce(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		atomic.AddInt32(&posts, 1)
+		// A slow post leaves time for the other sends to check the key
+		time.Sleep(50 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.000001"})
+	}))
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = runSend("C123", "v1.2 is out", &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour}, c)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts), "only one send with the key posts")
+	j, err := loadIdempotencyJournal(idempotencyJournalPath(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890.000001", j.Entries["release-v1.2"].TS)
+	assert.False(t, j.Entries["release-v1.2"].Pending)
+}
+
+func TestRunSend_IdempotencyFailedSendReleasesKey(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "channel_not_found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.000001"})
+	}))
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, idemKey: "release-v1.2", idemTTL: time.Hour}
+	require.Error(t, runSend("C123", "v1.2 is out", opts, c))
+
+	fail = false
+	require.NoError(t, runSend("C123", "v1.2 is out", opts, c), "a failed send doesn't hold the key")
+}
+
+func TestRunSend_UpdateExistingRequiresKey(t *testing.T) {
+	err := runSend("C123", "text", &sendOptions{updateExist: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--update-existing requires --idempotency-key")
+}
+
+func TestRunHistory_ShowsMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{
+						"ts": "1234567890.123456", "user": "U001", "text": "Build #42",
+						"metadata": map[string]interface{}{"event_type": "build", "event_payload": map[string]interface{}{"id": 42}},
+					},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runHistory("C123", &historyOptions{limit: 20}, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `metadata: build {"id":42}`)
+}
+
+// fakeEditor returns an editor that checks the file's initial contents and
+// replaces them with text
+func fakeEditor(t *testing.T, wantInitial, text string) func(string) error {
+	return func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, wantInitial, string(data))
+		return os.WriteFile(path, []byte(text), 0600)
+	}
+}
+
+func TestComposeInEditor(t *testing.T) {
+	text, err := composeInEditor("", fakeEditor(t, "", "Line one\n\nLine two\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Line one\n\nLine two", text)
+
+	_, err = composeInEditor("", fakeEditor(t, "", "  \n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "message is empty")
+
+	_, err = composeInEditor("Draft", fakeEditor(t, "Draft", "Draft\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "message is unchanged")
+
+	_, err = composeInEditor("", func(string) error { return fmt.Errorf("editor crashed") })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "editor crashed")
+}
+
+func TestRunSend_EditWithTemplate(t *testing.T) {
+	template := t.TempDir() + "/release.md"
+	require.NoError(t, os.WriteFile(template, []byte("*Release notes*\n"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "*Release notes*\n- faster sends", body["text"])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{
+		simple:   true,
+		edit:     true,
+		template: template,
+		editor:   fakeEditor(t, "*Release notes*\n", "*Release notes*\n- faster sends\n"),
+	}
+
+	require.NoError(t, runSend("C123", "", opts, c))
+}
+
+func TestRunSend_EditUnchangedAborts(t *testing.T) {
+	c := client.NewWithConfig("http://unused.invalid", "test-token", nil)
+	opts := &sendOptions{edit: true, editor: fakeEditor(t, "Draft", "Draft")}
+
+	err := runSend("C123", "Draft", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aborting: message is unchanged")
+}
+
+func TestRunSend_EditRejectsStdin(t *testing.T) {
+	err := runSend("C123", "-", &sendOptions{edit: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--edit cannot be combined with reading from stdin")
+
+	err = runSend("C123", "text", &sendOptions{template: "notes.md"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--template requires --edit")
+}
+
+func TestRunUpdate_Edit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"messages": []map[string]interface{}{{"ts": "1234567890.123456", "text": "Deploy at 5pm"}},
+			})
+		case "/chat.update":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "1234567890.123456", body["ts"])
+			assert.Equal(t, "Deploy at 6pm", body["text"])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &updateOptions{simple: true, edit: true, editor: fakeEditor(t, "Deploy at 5pm", "Deploy at 6pm\n")}
+
+	require.NoError(t, runUpdate("C123", "1234567890.123456", "", opts, c))
+	assert.Contains(t, buf.String(), "Message updated")
+}
+
+func TestRunUpdate_RequiresTextOrEdit(t *testing.T) {
+	err := runUpdate("C123", "1234567890.123456", "", &updateOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "or use --edit")
+
+	err = runUpdate("C123", "1234567890.123456", "text", &updateOptions{edit: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot pass text with --edit")
+}
+
+func mentionsServer(t *testing.T, sentText *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "members": []map[string]interface{}{
+				{"id": "U001", "name": "alice"},
+			}})
+		case "/conversations.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channels": []map[string]interface{}{
+				{"id": "C001", "name": "deploys"},
+			}})
+		case "/usergroups.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "usergroups": []map[string]interface{}{}})
+		case "/chat.postMessage", "/chat.update":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*sentText = body["text"].(string)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestRunSend_ResolveMentions(t *testing.T) {
+	var sentText string
+	server := mentionsServer(t, &sentText)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, mentions: true, unresolved: unresolvedFail}
+
+	require.NoError(t, runSend("C123", "@alice please check #deploys", opts, c))
+	assert.Equal(t, "<@U001> please check <#C001>", sentText)
+}
+
+func TestRunSend_ResolveMentionsUnresolved(t *testing.T) {
+	var sentText string
+	server := mentionsServer(t, &sentText)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runSend("C123", "@alice and @nobody", &sendOptions{simple: true, mentions: true, unresolved: unresolvedFail}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not resolve @nobody")
+	assert.Empty(t, sentText)
+
+	require.NoError(t, runSend("C123", "@alice and @nobody", &sendOptions{simple: true, mentions: true, unresolved: unresolvedWarn}, c))
+	assert.Equal(t, "<@U001> and @nobody", sentText)
+
+	err = runSend("C123", "hi", &sendOptions{mentions: true, unresolved: "ignore"}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --unresolved value")
+}
+
+func TestRunUpdate_ResolveMentions(t *testing.T) {
+	var sentText string
+	server := mentionsServer(t, &sentText)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &updateOptions{simple: true, mentions: true, unresolved: unresolvedFail}
+
+	require.NoError(t, runUpdate("C123", "1234567890.123456", "Handing over to @alice", opts, c))
+	assert.Equal(t, "Handing over to <@U001>", sentText)
+}
+
+func TestRunHistory_RendersMrkdwn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.123456", "user": "U001", "text": "<@U002> see <#C002|general> &amp; <https://example.com|docs> :rocket:\nsecond line"},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	require.NoError(t, runHistory("C123", &historyOptions{limit: 20}, c))
+	assert.Contains(t, buf.String(), "alice: @bob see #general & docs (https://example.com) 🚀\n    second line\n")
+
+	buf.Reset()
+	require.NoError(t, runHistory("C123", &historyOptions{limit: 20, raw: true}, c))
+	assert.Contains(t, buf.String(), "alice: <@U002> see <#C002|general> &amp; <https://example.com|docs> :rocket:\n")
+}
+
+func TestMessageBody_AttachmentsAndFiles(t *testing.T) {
+	m := client.Message{
+		Text:   "Build finished",
+		Edited: &client.Edited{User: "U1", TS: "1.2"},
+		Attachments: []client.Attachment{
+			{Title: "Build 42", TitleLink: "https://ci.example.com/42", Text: "*passed*",
+				Fields: []client.AttachmentField{{Title: "Branch", Value: "main"}}},
+			{Fallback: "legacy fallback"},
+		},
+		Files: []client.File{
+			{Name: "log.txt", Filetype: "text", Size: 2048, Permalink: "https://files.example.com/F1"},
+		},
+	}
+
+	got := messageBody(&mrkdwn.Renderer{}, m, false)
+	assert.Equal(t, "Build finished (edited)\n"+
+		"  [attachment] Build 42 - *passed* - Branch: main (https://ci.example.com/42)\n"+
+		"  [attachment] legacy fallback\n"+
+		"  [file] log.txt (text, 2.0 KiB) https://files.example.com/F1", got)
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KiB", formatSize(1536))
+	assert.Equal(t, "3.0 MiB", formatSize(3*1024*1024))
+}
+
+func TestTailEvent_MarshalJSON(t *testing.T) {
+	var m client.Message
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"message","user":"U1","text":"hi","ts":"1.2","client_msg_id":"x"}`), &m))
+
+	data, err := json.Marshal(tailEvent{Channel: "C1", UserName: "alice", Message: m})
+	require.NoError(t, err)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "C1", got["channel"])
+	assert.Equal(t, "alice", got["user_name"])
+	assert.Equal(t, "hi", got["text"])
+	assert.Equal(t, "x", got["client_msg_id"])
+}
+
+func TestRunReact_NormalizesPermalinkTimestamp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "1700000000.123456", body["timestamp"])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runReact("C123", "p1700000000123456", "eyes", &reactOptions{}, c)
+	require.NoError(t, err)
+}
+
+func TestRunGet_ThreadReplyPermalink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/conversations.replies", r.URL.Path)
+		assert.Equal(t, "C123", r.URL.Query().Get("channel"))
+		assert.Equal(t, "1700000000.000100", r.URL.Query().Get("ts"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"ts": "1700000000.000100", "user": "U001", "text": "parent"},
+				{"ts": "1700000000.123456", "thread_ts": "1700000000.000100", "user": "U001", "text": "the reply"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	ref, ok := validate.ParsePermalink("https://acme.slack.com/archives/C123/p1700000000123456?thread_ts=1700000000.000100&cid=C123")
+	require.True(t, ok)
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runGet(ref, &getOptions{}, c))
+
+	var msg client.Message
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &msg))
+	assert.Equal(t, "the reply", msg.Text)
+}
+
+func TestRunGet_Text(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000000.123456", "user": "U001", "text": "ship it", "reply_count": 2, "latest_reply": "1700000100.000000",
+						"reactions": []map[string]interface{}{{"name": "eyes", "count": 3}}},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	ref := validate.MessageRef{Channel: "C123", TS: "1700000000.123456"}
+	require.NoError(t, runGet(ref, &getOptions{raw: true}, c))
+
+	assert.Contains(t, buf.String(), "ship it")
+	assert.Contains(t, buf.String(), "2 replies")
+	assert.Contains(t, buf.String(), ":eyes: 3")
+}
+
+func TestRunPermalink_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.getPermalink", r.URL.Path)
+		assert.Equal(t, "C123", r.URL.Query().Get("channel"))
+		assert.Equal(t, "1700000001.000001", r.URL.Query().Get("message_ts"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":        true,
+			"permalink": "https://acme.slack.com/archives/C123/p1700000001000001",
+		})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runPermalink("C123", "p1700000001000001", &permalinkOptions{}, c))
+
+	var result permalinkResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "C123", result.Channel)
+	assert.Equal(t, "1700000001.000001", result.TS)
+	assert.Equal(t, "https://acme.slack.com/archives/C123/p1700000001000001", result.Permalink)
+}
+
+func TestRunHistory_Permalinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000002.000001", "user": "U001", "text": "Hello"},
+				},
+			})
+		case "/chat.getPermalink":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":        true,
+				"permalink": "https://acme.slack.com/archives/C123/p" + strings.ReplaceAll(r.URL.Query().Get("message_ts"), ".", ""),
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &historyOptions{limit: 20, raw: true, permalink: true}
+	require.NoError(t, runHistory("C123", opts, c))
+
+	assert.Contains(t, buf.String(), "https://acme.slack.com/archives/C123/p1700000002000001")
+}
+
+func TestRunSend_Permalink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"ts":      "1700000003.000001",
+				"channel": "C123",
+			})
+		case "/chat.getPermalink":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":        true,
+				"permalink": "https://acme.slack.com/archives/C123/p1700000003000001",
+			})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{simple: true, permalink: true}
+	require.NoError(t, runSend("C123", "Hello", opts, c))
+
+	assert.Equal(t, "Message sent (ts: 1700000003.000001)\n  https://acme.slack.com/archives/C123/p1700000003000001\n", buf.String())
+}
+
+// newPurgeServer serves a channel with bot and user messages, one bot
+// message having a thread. Deletes of the timestamps in failures fail with
+// the given error, and the first delete is rate limited.
+func newPurgeServer(t *testing.T, failures map[string]string) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var deleted []string
+	rateLimited := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			var messages []map[string]interface{}
+			if r.URL.Query().Get("latest") == "" || r.URL.Query().Get("latest") == "1700000450.000000" {
+				messages = []map[string]interface{}{
+					{"ts": "1700000500.000100", "bot_id": "B1", "subtype": "bot_message", "text": "disk usage 91%", "reply_count": 2, "thread_ts": "1700000500.000100"},
+					{"ts": "1700000400.000100", "user": "U001", "text": "disk is fine"},
+					{"ts": "1700000300.000100", "bot_id": "B1", "subtype": "bot_message", "text": "disk space low"},
+					{"ts": "1700000100.000100", "bot_id": "B1", "subtype": "bot_message", "text": "deploy finished"},
+				}
+			}
+			oldest := r.URL.Query().Get("oldest")
+			var page []map[string]interface{}
+			for _, m := range messages {
+				if oldest == "" || client.CompareTimestamps(m["ts"].(string), oldest) > 0 {
+					page = append(page, m)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": page})
+		case "/conversations.replies":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000500.000100", "bot_id": "B1", "subtype": "bot_message", "text": "disk usage 91%"},
+					{"ts": "1700000550.000100", "bot_id": "B1", "subtype": "bot_message", "text": "disk usage 95%", "thread_ts": "1700000500.000100"},
+					{"ts": "1700000560.000100", "user": "U002", "text": "on it, disk cleanup running", "thread_ts": "1700000500.000100"},
+				},
+			})
+		case "/chat.delete":
+			mu.Lock()
+			defer mu.Unlock()
+			if !rateLimited {
+				rateLimited = true
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ts := body["ts"].(string)
+			if code, ok := failures[ts]; ok {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": code})
+				return
+			}
+			deleted = append(deleted, ts)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sorted := append([]string(nil), deleted...)
+		sort.Strings(sorted)
+		return sorted
+	}
+}
+
+func readPurgeRecord(t *testing.T, path string) purgeRecord {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var record purgeRecord
+	require.NoError(t, json.Unmarshal(data, &record))
+	return record
+}
+
+func TestRunPurge_DeletesMatches(t *testing.T) {
+	server, deleted := newPurgeServer(t, nil)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	recordPath := filepath.Join(t.TempDir(), "record.json")
+	opts := &purgeOptions{fromBot: true, match: "^disk", includeThreads: true, concurrency: 2, record: recordPath, force: true}
+	require.NoError(t, runPurge(context.Background(), "C123", opts, c))
+
+	assert.Equal(t, []string{"1700000300.000100", "1700000500.000100", "1700000550.000100"}, deleted())
+	assert.Contains(t, buf.String(), "Deleted 1700000550.000100\n")
+	assert.Contains(t, buf.String(), "Deleted 3 of 3 messages; record written to "+recordPath)
+
+	record := readPurgeRecord(t, recordPath)
+	assert.Equal(t, "C123", record.Channel)
+	require.Len(t, record.Messages, 3)
+	for _, r := range record.Messages {
+		assert.Equal(t, "deleted", r.Status)
+	}
+	assert.Equal(t, "disk usage 91%", record.Messages[0].Message.Text, "the record keeps the deleted text")
+}
+
+func TestRunPurge_ReportsFailures(t *testing.T) {
+	server, deleted := newPurgeServer(t, map[string]string{"1700000300.000100": "cant_delete_message"})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	recordPath := filepath.Join(t.TempDir(), "record.json")
+	opts := &purgeOptions{fromBot: true, concurrency: 4, record: recordPath, force: true}
+	err := runPurge(context.Background(), "C123", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 messages couldn't be deleted")
+
+	assert.Equal(t, []string{"1700000100.000100", "1700000500.000100"}, deleted())
+	assert.Contains(t, buf.String(), "Failed 1700000300.000100: cant_delete_message\n")
+
+	record := readPurgeRecord(t, recordPath)
+	for _, r := range record.Messages {
+		if r.Message.TS == "1700000300.000100" {
+			assert.Equal(t, "failed", r.Status)
+			assert.Equal(t, "cant_delete_message", r.Error)
+		} else {
+			assert.Equal(t, "deleted", r.Status)
+		}
+	}
+}
+
+func TestRunPurge_DryRun(t *testing.T) {
+	server, deleted := newPurgeServer(t, nil)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &purgeOptions{fromBot: true, after: "1700000200.000000", before: "1700000450.000000", concurrency: 1, dryRun: true}
+	require.NoError(t, runPurge(context.Background(), "C123", opts, c))
+
+	var candidates []client.Message
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &candidates))
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "1700000300.000100", candidates[0].TS)
+	assert.Empty(t, deleted())
+}
+
+func TestRunPurge_Confirmation(t *testing.T) {
+	server, deleted := newPurgeServer(t, nil)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	recordPath := filepath.Join(t.TempDir(), "record.json")
+	opts := &purgeOptions{users: []string{"U001"}, concurrency: 1, record: recordPath, stdin: strings.NewReader("n\n")}
+	require.NoError(t, runPurge(context.Background(), "C123", opts, c))
+
+	assert.Contains(t, buf.String(), "alice: disk is fine")
+	assert.Contains(t, buf.String(), "About to delete 1 message in channel C123")
+	assert.Contains(t, buf.String(), "Cancelled.")
+	assert.Empty(t, deleted())
+	_, err := os.Stat(recordPath)
+	assert.True(t, os.IsNotExist(err), "nothing is recorded when cancelled")
+}
+
+func TestRunPurge_NoAnswerDoesNotDelete(t *testing.T) {
+	server, deleted := newPurgeServer(t, nil)
+	defer server.Close()
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	recordPath := filepath.Join(t.TempDir(), "record.json")
+	opts := &purgeOptions{fromBot: true, concurrency: 1, record: recordPath, stdin: strings.NewReader("")}
+	err := runPurge(context.Background(), "C123", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	assert.Empty(t, deleted(), "closed stdin is not a yes")
+	_, err = os.Stat(recordPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunPurge_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts purgeOptions
+		want string
+	}{
+		{"no filters", purgeOptions{concurrency: 1}, "at least one filter is required"},
+		{"bad concurrency", purgeOptions{fromBot: true}, "--concurrency must be at least 1"},
+		{"bad regex", purgeOptions{match: "(", concurrency: 1}, "invalid --match"},
+		{"bad time", purgeOptions{before: "someday", concurrency: 1}, "--before"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runPurge(context.Background(), "C123", &tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
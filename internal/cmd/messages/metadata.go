@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+const (
+	// upsertPayloadKey is the metadata payload field that carries an --upsert-key
+	upsertPayloadKey = "slck_upsert_key"
+	// defaultMetadataType is the event type used when --upsert-key is given without --metadata-type
+	defaultMetadataType = "slck_message"
+	// upsertSearchLimit is how many recent messages are searched for an --upsert-key match
+	upsertSearchLimit = 200
+)
+
+// buildMetadata builds message metadata from --metadata-type, --metadata-json and --upsert-key.
+// It returns nil if none of them are set.
+func buildMetadata(eventType, payloadJSON, upsertKey string) (*client.MessageMetadata, error) {
+	if eventType == "" && payloadJSON == "" && upsertKey == "" {
+		return nil, nil
+	}
+
+	payload := map[string]interface{}{}
+	if payloadJSON != "" {
+		if err := json.Unmarshal([]byte(payloadJSON), &payload); err != nil {
+			return nil, fmt.Errorf("invalid --metadata-json: must be a JSON object: %w", err)
+		}
+	}
+
+	if eventType == "" {
+		if upsertKey == "" {
+			return nil, fmt.Errorf("--metadata-type is required with --metadata-json")
+		}
+		eventType = defaultMetadataType
+	}
+
+	if upsertKey != "" {
+		payload[upsertPayloadKey] = upsertKey
+	}
+
+	return &client.MessageMetadata{
+		EventType:    eventType,
+		EventPayload: payload,
+	}, nil
+}
+
+// findUpsertTarget searches recent messages in a channel (or thread) for one posted
+// by the current bot or user whose metadata carries key. It returns nil if none is found.
+func findUpsertTarget(c *client.Client, channelID, threadTS, key string) (*client.Message, error) {
+	auth, err := c.AuthTest()
+	if err != nil {
+		return nil, client.WrapError("identify current user", err)
+	}
+
+	var messages []client.Message
+	if threadTS != "" {
+		messages, err = c.GetThreadReplies(channelID, threadTS, upsertSearchLimit)
+	} else {
+		messages, err = c.GetChannelHistory(channelID, upsertSearchLimit, "", "")
+	}
+	if err != nil {
+		return nil, client.WrapError("search for message to update", err)
+	}
+
+	for i := range messages {
+		m := &messages[i]
+		if m.Metadata == nil || m.Metadata.EventPayload[upsertPayloadKey] != key {
+			continue
+		}
+		if m.User == auth.UserID || (auth.BotID != "" && m.BotID == auth.BotID) {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
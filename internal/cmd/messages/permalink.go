@@ -0,0 +1,109 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type permalinkOptions struct{}
+
+// permalinkResult is the JSON output of messages permalink
+type permalinkResult struct {
+	Channel   string `json:"channel"`
+	TS        string `json:"ts"`
+	Permalink string `json:"permalink"`
+}
+
+func newPermalinkCmd() *cobra.Command {
+	opts := &permalinkOptions{}
+
+	return &cobra.Command{
+		Use:   "permalink <channel> <timestamp>",
+		Short: "Print a message's permalink",
+		Long: `Print the permalink of a message, for sharing it outside the terminal.
+
+Permalinks never change, so they are cached under the config directory and
+repeated lookups don't call the Slack API.
+
+Examples:
+  slck messages permalink deploys 1234567890.123456
+  slck messages permalink C1234567890 p1234567890123456 -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermalink(args[0], args[1], opts, nil)
+		},
+	}
+}
+
+func runPermalink(channel, timestamp string, opts *permalinkOptions, c *client.Client) error {
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	link, err := c.GetPermalink(channelID, timestamp)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get permalink for %s", timestamp), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(permalinkResult{Channel: channelID, TS: timestamp, Permalink: link})
+	}
+
+	output.Println(link)
+	return nil
+}
+
+// addPermalinks fills in the permalink of each message, for --permalink
+func addPermalinks(c *client.Client, channelID string, msgs []client.Message) error {
+	for i := range msgs {
+		link, err := c.GetPermalink(channelID, msgs[i].TS)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get permalink for %s", msgs[i].TS), err)
+		}
+		msgs[i].Permalink = link
+	}
+	return nil
+}
+
+// printSent reports a message that was just posted or updated, with its
+// permalink when withLink is set. The message was posted either way, so a
+// failed permalink lookup is only a warning.
+func printSent(c *client.Client, channelID string, msg *client.Message, format string, withLink bool) error {
+	if withLink {
+		link, err := c.GetPermalink(channelID, msg.TS)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not get permalink for %s: %v\n", msg.TS, err)
+		}
+		msg.Permalink = link
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(msg)
+	}
+	output.Printf(format+"\n", msg.TS)
+	if msg.Permalink != "" {
+		output.Printf("  %s\n", msg.Permalink)
+	}
+	return nil
+}
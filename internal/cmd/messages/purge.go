@@ -0,0 +1,446 @@
+package messages
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+)
+
+const (
+	// purgePageSize is how many messages are requested per history call
+	purgePageSize = 200
+
+	// purgeMaxThreadReplies bounds the replies scanned in a single thread
+	purgeMaxThreadReplies = 100000
+)
+
+type purgeOptions struct {
+	fromBot        bool
+	users          []string
+	before         string
+	after          string
+	match          string
+	includeThreads bool
+	concurrency    int
+	record         string
+	dryRun         bool
+	force          bool
+	stdin          io.Reader // For testing
+}
+
+// purgeResult is the outcome of deleting one message
+type purgeResult struct {
+	Status  string         `json:"status"` // deleted, failed or pending
+	Error   string         `json:"error,omitempty"`
+	Message client.Message `json:"message"`
+}
+
+// purgeRecord is the JSON record of a purge, kept so deleted text can be recovered
+type purgeRecord struct {
+	Channel  string        `json:"channel"`
+	PurgedAt time.Time     `json:"purged_at"`
+	Messages []purgeResult `json:"messages"`
+}
+
+func newPurgeCmd() *cobra.Command {
+	opts := &purgeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "purge <channel>",
+		Short: "Delete the messages in a channel that match filters",
+		Long: `Delete every message in a channel that matches all of the given filters,
+for example to clean up after a misbehaving bot. At least one filter is
+required.
+
+Matching messages are listed first and deleted once you confirm (or straight
+away with --force). If nothing answers the prompt, as in a script or CI job,
+nothing is deleted. Use --dry-run to only list them. Deletes run concurrently;
+rate limited requests pause all of them for the wait Slack asks for.
+
+The result for each message is reported, such as cant_delete_message for a
+message the token isn't allowed to delete: bot tokens can only delete the
+bot's own messages, while a workspace admin's user token can delete anyone's.
+
+Before anything is deleted, the matching messages are written to a JSON
+record (by default in the purged directory under the config directory), so
+their text can be recovered.
+
+--before and --after accept a Slack timestamp or:
+` + timeparse.Syntax + `
+
+Examples:
+  slck messages purge alerts --from-bot --after "7 days ago" --dry-run
+  slck messages purge alerts --from-bot --match "disk (usage|space)" --include-threads
+  slck messages purge general --user U0123456789 --before 2024-01-01 --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runPurge(ctx, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.fromBot, "from-bot", false, "Only messages posted by bots and apps")
+	cmd.Flags().StringSliceVar(&opts.users, "user", nil, "Only messages by this user ID or name (repeatable)")
+	cmd.Flags().StringVar(&opts.before, "before", "", "Only messages before this time")
+	cmd.Flags().StringVar(&opts.after, "after", "", "Only messages after this time")
+	cmd.Flags().StringVar(&opts.match, "match", "", "Only messages whose text matches this regex")
+	cmd.Flags().BoolVar(&opts.includeThreads, "include-threads", false, "Also delete matching thread replies")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Number of deletes to run at once")
+	cmd.Flags().StringVar(&opts.record, "record", "", "File to record the deleted messages in")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "List the matching messages without deleting them")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runPurge(ctx context.Context, channel string, opts *purgeOptions, c *client.Client) error {
+	if !opts.fromBot && len(opts.users) == 0 && opts.before == "" && opts.after == "" && opts.match == "" {
+		return fmt.Errorf("at least one filter is required (--from-bot, --user, --before, --after or --match)")
+	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	latest, err := timeparse.Bound(opts.before, "--before")
+	if err != nil {
+		return err
+	}
+	oldest, err := timeparse.Bound(opts.after, "--after")
+	if err != nil {
+		return err
+	}
+	var match *regexp.Regexp
+	if opts.match != "" {
+		if match, err = regexp.Compile(opts.match); err != nil {
+			return fmt.Errorf("invalid --match: %w", err)
+		}
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	p := &purger{ctx: ctx, client: c, channelID: channelID, opts: opts, match: match, oldest: oldest, latest: latest}
+	if len(opts.users) > 0 {
+		p.users = make(map[string]bool)
+		for _, u := range opts.users {
+			id, err := c.ResolveUser(u)
+			if err != nil {
+				return err
+			}
+			p.users[id] = true
+		}
+	}
+
+	candidates, err := p.scan()
+	if err != nil {
+		return err
+	}
+
+	if len(candidates) == 0 {
+		if output.IsJSON() {
+			return output.PrintJSON([]purgeResult{})
+		}
+		output.Println("No messages match")
+		return nil
+	}
+
+	if opts.dryRun {
+		if output.IsJSON() {
+			return output.PrintJSON(candidates)
+		}
+		p.list(candidates)
+		output.Printf("%d %s would be deleted (dry run)\n", len(candidates), pluralMessages(len(candidates)))
+		return nil
+	}
+
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		p.list(candidates)
+		output.Printf("About to delete %d %s in channel %s\n", len(candidates), pluralMessages(len(candidates)), channel)
+		output.Printf("Are you sure? [y/N]: ")
+
+		// Unlike a single delete, no answer (such as stdin closed in CI) never
+		// goes ahead with a purge
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println()
+			return fmt.Errorf("no answer to the confirmation prompt; use --force to purge without confirming")
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	record := &purgeRecord{Channel: channelID, PurgedAt: time.Now()}
+	for _, m := range candidates {
+		record.Messages = append(record.Messages, purgeResult{Status: "pending", Message: m})
+	}
+	recordPath := opts.record
+	if recordPath == "" {
+		recordPath = filepath.Join(keychain.ConfigDir(), "purged",
+			fmt.Sprintf("%s-%s.json", channelID, record.PurgedAt.Format("20060102-150405")))
+	}
+	if err := writePurgeRecord(recordPath, record); err != nil {
+		return fmt.Errorf("writing purge record: %w", err)
+	}
+
+	p.delete(record.Messages)
+
+	if err := writePurgeRecord(recordPath, record); err != nil {
+		return fmt.Errorf("writing purge record: %w", err)
+	}
+
+	deleted, failed := 0, 0
+	for _, r := range record.Messages {
+		switch r.Status {
+		case "deleted":
+			deleted++
+		case "failed":
+			failed++
+		}
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(record.Messages); err != nil {
+			return err
+		}
+	} else {
+		output.Printf("Deleted %d of %d %s; record written to %s\n", deleted, len(candidates), pluralMessages(len(candidates)), recordPath)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("purge interrupted; %d of %d messages deleted", deleted, len(candidates))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d messages couldn't be deleted", failed, len(candidates))
+	}
+	return nil
+}
+
+// purger finds and deletes the messages matching a purge's filters
+type purger struct {
+	ctx       context.Context
+	client    *client.Client
+	channelID string
+	opts      *purgeOptions
+	users     map[string]bool
+	match     *regexp.Regexp
+	oldest    string
+	latest    string
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// scan returns the matching messages, newest first, with matching replies
+// after their thread's parent
+func (p *purger) scan() ([]client.Message, error) {
+	var candidates []client.Message
+	latest := p.latest
+	for {
+		var page []client.Message
+		if err := p.call(func() (err error) {
+			page, err = p.client.GetChannelHistory(p.channelID, purgePageSize, p.oldest, latest)
+			return err
+		}); err != nil {
+			return nil, client.WrapError("get history", err)
+		}
+		if len(page) == 0 {
+			return candidates, nil
+		}
+
+		for _, m := range page {
+			if p.matches(m) {
+				candidates = append(candidates, m)
+			}
+			if !p.opts.includeThreads || m.ReplyCount == 0 {
+				continue
+			}
+			var replies []client.Message
+			if err := p.call(func() (err error) {
+				replies, err = p.client.GetThreadReplies(p.channelID, m.TS, purgeMaxThreadReplies)
+				return err
+			}); err != nil {
+				return nil, client.WrapError(fmt.Sprintf("get thread %s", m.TS), err)
+			}
+			for _, r := range replies {
+				if r.TS != m.TS && p.matches(r) {
+					candidates = append(candidates, r)
+				}
+			}
+		}
+		latest = page[len(page)-1].TS
+	}
+}
+
+// matches reports whether a message passes every filter
+func (p *purger) matches(m client.Message) bool {
+	if m.Subtype == "tombstone" {
+		return false // Placeholder for a deleted thread parent
+	}
+	if p.oldest != "" && client.CompareTimestamps(m.TS, p.oldest) <= 0 {
+		return false
+	}
+	if p.latest != "" && client.CompareTimestamps(m.TS, p.latest) >= 0 {
+		return false
+	}
+	if p.opts.fromBot && m.BotID == "" && m.Subtype != "bot_message" {
+		return false
+	}
+	if p.users != nil && !p.users[m.User] {
+		return false
+	}
+	if p.match != nil && !p.match.MatchString(m.Text) {
+		return false
+	}
+	return true
+}
+
+// list prints the matching messages
+func (p *purger) list(candidates []client.Message) {
+	resolver := client.NewUserResolver(p.client)
+	for _, m := range candidates {
+		text := strings.ReplaceAll(m.Text, "\n", " ")
+		if len([]rune(text)) > 80 {
+			text = string([]rune(text)[:77]) + "..."
+		}
+		prefix := ""
+		if m.ThreadTS != "" && m.ThreadTS != m.TS {
+			prefix = "  reply: "
+		}
+		output.Printf("%s[%s] %s %s: %s\n", prefix, formatTimestamp(m.TS), m.TS, resolver.Author(m), text)
+	}
+}
+
+// delete deletes the messages concurrently, recording each result
+func (p *purger) delete(results []purgeResult) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var outMu sync.Mutex
+
+	for w := 0; w < p.opts.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				m := results[i].Message
+				err := p.call(func() error { return p.client.DeleteMessage(p.channelID, m.TS) })
+				if err == nil {
+					results[i].Status = "deleted"
+				} else if p.ctx.Err() == nil {
+					results[i].Status = "failed"
+					results[i].Error = strings.TrimPrefix(err.Error(), "slack API error: ")
+				}
+
+				if output.IsJSON() || results[i].Status == "pending" {
+					continue
+				}
+				outMu.Lock()
+				if results[i].Status == "deleted" {
+					output.Printf("Deleted %s\n", m.TS)
+				} else {
+					output.Printf("Failed %s: %s\n", m.TS, results[i].Error)
+				}
+				outMu.Unlock()
+			}
+		}()
+	}
+
+	for i := range results {
+		if p.ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// call runs fn, retrying rate limited attempts. A rate limit pauses every
+// worker, not just the one that hit it, until Slack's wait is over.
+func (p *purger) call(fn func() error) error {
+	return client.RetryRateLimited(p.ctx, client.MaxRateLimitRetries, func() error {
+		if err := p.waitPause(); err != nil {
+			return err
+		}
+		err := fn()
+		if wait, limited := client.IsRateLimited(err); limited {
+			p.mu.Lock()
+			if until := time.Now().Add(wait); until.After(p.pausedUntil) {
+				p.pausedUntil = until
+			}
+			p.mu.Unlock()
+		}
+		return err
+	})
+}
+
+// waitPause waits out a rate limit pause
+func (p *purger) waitPause() error {
+	p.mu.Lock()
+	wait := time.Until(p.pausedUntil)
+	p.mu.Unlock()
+	if wait <= 0 {
+		return p.ctx.Err()
+	}
+	if !client.SleepContext(p.ctx, wait) {
+		return p.ctx.Err()
+	}
+	return nil
+}
+
+// writePurgeRecord writes the purge record atomically
+func writePurgeRecord(path string, record *purgeRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func pluralMessages(n int) string {
+	if n == 1 {
+		return "message"
+	}
+	return "messages"
+}
@@ -16,11 +16,18 @@ func newReactCmd() *cobra.Command {
 	opts := &reactOptions{}
 
 	return &cobra.Command{
-		Use:   "react <channel> <timestamp> <emoji>",
+		Use:   "react <channel> <timestamp> <emoji> | react <permalink> <emoji>",
 		Short: "Add a reaction to a message",
-		Args:  cobra.ExactArgs(3),
+		Args:  cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReact(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) != 1 {
+				return fmt.Errorf("expected an emoji after the message")
+			}
+			return runReact(ref.Channel, ref.TS, rest[0], opts, nil)
 		},
 	}
 }
@@ -30,6 +37,7 @@ func runReact(channel, timestamp, emoji string, opts *reactOptions, c *client.Cl
 	if err := validate.Timestamp(timestamp); err != nil {
 		return err
 	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
 
 	// Normalize emoji (remove colons)
 	emoji = validate.Emoji(emoji)
@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type reactionsOptions struct{}
+
+// reactionSummary is a reaction with its reacting users' display names, as printed in JSON mode
+type reactionSummary struct {
+	client.Reaction
+	UserNames []string `json:"user_names"`
+}
+
+func newReactionsCmd() *cobra.Command {
+	opts := &reactionsOptions{}
+
+	return &cobra.Command{
+		Use:   "reactions <channel> <timestamp> | reactions <permalink>",
+		Short: "Show who reacted to a message",
+		Long: `Show each reaction on a message with its count and the users who added it.
+
+Examples:
+  slck messages reactions C1234567890 1234567890.123456
+  slck messages reactions https://workspace.slack.com/archives/C1234567890/p1234567890123456
+  slck messages reactions deploys 1234567890.123456 -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the message", rest[0])
+			}
+			return runReactions(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+}
+
+func runReactions(channel, timestamp string, opts *reactionsOptions, c *client.Client) error {
+	// Validate and normalize timestamp (accepts API format, p-prefixed, or full URL)
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	msg, err := c.GetReactions(channelID, timestamp)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get reactions for %s", timestamp), err)
+	}
+
+	resolver := client.NewUserResolver(c)
+	summaries := make([]reactionSummary, 0, len(msg.Reactions))
+	for _, r := range msg.Reactions {
+		names := make([]string, 0, len(r.Users))
+		for _, u := range r.Users {
+			names = append(names, resolver.Resolve(u))
+		}
+		summaries = append(summaries, reactionSummary{Reaction: r, UserNames: names})
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(summaries)
+	}
+
+	if len(summaries) == 0 {
+		output.Println("No reactions")
+		return nil
+	}
+
+	if output.IsTable() {
+		headers := []string{"EMOJI", "COUNT", "USERS"}
+		rows := make([][]string, 0, len(summaries))
+		for _, s := range summaries {
+			rows = append(rows, []string{":" + s.Name + ":", fmt.Sprintf("%d", s.Count), strings.Join(s.UserNames, ", ")})
+		}
+		output.Table(headers, rows)
+		return nil
+	}
+
+	for _, s := range summaries {
+		output.Printf(":%s: %d  %s\n", s.Name, s.Count, strings.Join(s.UserNames, ", "))
+	}
+	return nil
+}
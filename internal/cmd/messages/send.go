@@ -7,6 +7,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
@@ -24,7 +27,21 @@ type sendOptions struct {
 	noUnfurl    bool
 	files       []string
 	fileTitle   string
-	stdin       io.Reader // For testing
+	split       bool
+	overflow    string
+	metaType    string
+	metaJSON    string
+	upsertKey   string
+	idemKey     string
+	idemTTL     time.Duration
+	updateExist bool
+	edit        bool
+	template    string
+	mentions    bool
+	unresolved  string
+	permalink   bool
+	stdin       io.Reader               // For testing
+	editor      func(path string) error // For testing
 }
 
 func newSendCmd() *cobra.Command {
@@ -42,6 +59,12 @@ Use "-" as the text argument to read message text from stdin:
   echo "Hello" | slck messages send C1234567890 -
   cat message.txt | slck messages send C1234567890 -
 
+Use --edit to write the message in $EDITOR (or $VISUAL). The editor starts
+with the text argument, or the contents of --template, and the saved text is
+sent. Saving an empty or unchanged message aborts:
+  slck messages send announcements --edit
+  slck messages send announcements --edit --template ./release-notes.md
+
 BLOCK KIT OPTIONS
 
 Text is optional when providing blocks via any of these methods:
@@ -71,7 +94,62 @@ Examples:
   slck messages send C1234567890 "Here's the report" --file ./report.pdf
   slck messages send C1234567890 --file ./report.pdf --thread 1234567890.123456
   slck messages send C1234567890 --file ./report.pdf --file-title "Monthly Report"
-  slck messages send C1234567890 --file ./a.csv --file ./b.csv`,
+  slck messages send C1234567890 --file ./a.csv --file ./b.csv
+
+LONG MESSAGES
+
+Slack rejects messages longer than 3,000 characters in Block Kit mode
+(40,000 with --simple). Use --overflow to handle oversized text:
+
+  --split           Split the text on paragraph, line or code block
+                    boundaries. The first part is posted normally and the
+                    rest are posted as replies in its thread.
+                    Same as --overflow=split.
+  --overflow=file   Upload the full text as a file instead.
+
+Examples:
+  cat build.log | slck messages send C1234567890 - --split
+  cat build.log | slck messages send C1234567890 - --overflow=file
+
+METADATA AND UPSERTS
+
+  --metadata-type   Event type of metadata to attach to the message.
+  --metadata-json   Metadata payload as a JSON object.
+  --upsert-key      Update the most recent message this app posted with the
+                    same key (searching the last 200 messages of the channel,
+                    or of --thread) instead of posting a new one. The key is
+                    stored in the message metadata.
+
+Examples:
+  slck messages send builds "Build #42 started" --metadata-type build --metadata-json '{"id":42}'
+  slck messages send builds "Build #42: passing" --upsert-key build-42
+
+MENTIONS
+
+  --resolve-mentions  Turn @handles, @usergroups, #channel names and email
+                      addresses in the text into real Slack mentions.
+                      Code spans and --blocks payloads are left as written.
+  --unresolved        What to do when a mention matches nobody: fail
+                      (default) or warn and send it as plain text.
+
+Examples:
+  slck messages send ops "@alice please check #deploys" --resolve-mentions
+  slck messages send ops "@oncall heads up" --resolve-mentions --unresolved=warn
+
+IDEMPOTENT SENDS
+
+  --idempotency-key   Remember the message sent with this key in a local
+                      journal. Sending again with the same key does nothing
+                      and prints the original timestamp. A send with a key
+                      that another send is still using fails instead.
+  --update-existing   With --idempotency-key, update the original message
+                      instead of doing nothing.
+  --idempotency-ttl   How long this key is remembered (default 24h). Each
+                      key keeps the TTL it was sent with.
+
+Examples:
+  slck messages send releases "v1.2 is out" --idempotency-key release-v1.2
+  slck messages send releases "v1.2 is out (fixed link)" --idempotency-key release-v1.2 --update-existing`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			text := ""
@@ -90,6 +168,19 @@ Examples:
 	cmd.Flags().BoolVar(&opts.noUnfurl, "no-unfurl", false, "Disable link preview unfurling")
 	cmd.Flags().StringArrayVar(&opts.files, "file", nil, "File(s) to upload (can be specified multiple times)")
 	cmd.Flags().StringVar(&opts.fileTitle, "file-title", "", "Custom title for uploaded file(s)")
+	cmd.Flags().BoolVar(&opts.split, "split", false, "Split long text into threaded parts (same as --overflow=split)")
+	cmd.Flags().StringVar(&opts.overflow, "overflow", "", "How to handle text over Slack's length limit: split or file")
+	cmd.Flags().StringVar(&opts.metaType, "metadata-type", "", "Event type of metadata to attach")
+	cmd.Flags().StringVar(&opts.metaJSON, "metadata-json", "", "Metadata payload as a JSON object")
+	cmd.Flags().StringVar(&opts.upsertKey, "upsert-key", "", "Update this app's earlier message with the same key instead of posting")
+	cmd.Flags().StringVar(&opts.idemKey, "idempotency-key", "", "Skip sending if a message was already sent with this key")
+	cmd.Flags().DurationVar(&opts.idemTTL, "idempotency-ttl", defaultIdempotencyTTL, "How long this idempotency key is remembered")
+	cmd.Flags().BoolVar(&opts.updateExist, "update-existing", false, "Update the message sent earlier with --idempotency-key")
+	cmd.Flags().BoolVar(&opts.edit, "edit", false, "Compose the message in $EDITOR")
+	cmd.Flags().StringVar(&opts.template, "template", "", "File to start the message from (with --edit)")
+	cmd.Flags().BoolVar(&opts.mentions, "resolve-mentions", false, "Turn @user, @group, #channel and emails into Slack mentions")
+	cmd.Flags().StringVar(&opts.unresolved, "unresolved", unresolvedFail, "What to do with mentions that can't be resolved: fail or warn")
+	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "Also print the message's permalink (one extra API call)")
 
 	return cmd
 }
@@ -118,6 +209,29 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		return fmt.Errorf("only one of --blocks, --blocks-file, or --blocks-stdin can be specified")
 	}
 
+	// Validate overflow handling
+	if opts.split {
+		if opts.overflow != "" && opts.overflow != overflowSplit {
+			return fmt.Errorf("--split cannot be used with --overflow=%s", opts.overflow)
+		}
+		opts.overflow = overflowSplit
+	}
+	if opts.overflow != "" && opts.overflow != overflowSplit && opts.overflow != overflowFile {
+		return fmt.Errorf("invalid overflow mode %q: must be one of: %s, %s", opts.overflow, overflowSplit, overflowFile)
+	}
+
+	if opts.mentions {
+		if err := validateUnresolvedMode(opts.unresolved); err != nil {
+			return err
+		}
+	}
+	if opts.template != "" && !opts.edit {
+		return fmt.Errorf("--template requires --edit")
+	}
+	if opts.edit && (text == "-" || opts.blocksStdin) {
+		return fmt.Errorf("--edit cannot be combined with reading from stdin")
+	}
+
 	// Read from stdin if text is "-"
 	if text == "-" {
 		if opts.blocksStdin {
@@ -144,6 +258,22 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 	// Unescape shell-escaped characters (e.g., \! from zsh)
 	text = unescapeShellChars(text)
 
+	if opts.edit {
+		initial := text
+		if opts.template != "" {
+			data, err := os.ReadFile(opts.template)
+			if err != nil {
+				return fmt.Errorf("reading template: %w", err)
+			}
+			initial = string(data)
+		}
+		edited, err := composeInEditor(initial, opts.editor)
+		if err != nil {
+			return err
+		}
+		text = edited
+	}
+
 	// Determine blocks source
 	var blocksSource string
 	if opts.blocksJSON != "" {
@@ -179,9 +309,30 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 	if text == "" && !hasBlocks && !hasFiles {
 		return fmt.Errorf("message text cannot be empty (or provide blocks via --blocks, --blocks-file, --blocks-stdin, or files via --file)")
 	}
+	if opts.overflow != "" && (hasBlocks || hasFiles) {
+		return fmt.Errorf("--split and --overflow cannot be used with blocks or files")
+	}
+
+	metadata, err := buildMetadata(opts.metaType, opts.metaJSON, opts.upsertKey)
+	if err != nil {
+		return err
+	}
+	if metadata != nil && (hasFiles || opts.overflow != "") {
+		return fmt.Errorf("metadata and --upsert-key cannot be used with files, --split or --overflow")
+	}
+	if opts.updateExist && opts.idemKey == "" {
+		return fmt.Errorf("--update-existing requires --idempotency-key")
+	}
+	if opts.idemKey != "" {
+		if hasFiles || opts.overflow != "" || opts.upsertKey != "" {
+			return fmt.Errorf("--idempotency-key cannot be used with files, --split, --overflow or --upsert-key")
+		}
+		if opts.idemTTL <= 0 {
+			return fmt.Errorf("--idempotency-ttl must be positive")
+		}
+	}
 
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
@@ -194,11 +345,30 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		return err
 	}
 
+	if opts.mentions && text != "" {
+		text, err = encodeMentions(c, text, opts.unresolved)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Handle file uploads
 	if hasFiles {
 		return uploadFiles(c, channelID, text, opts)
 	}
 
+	// Handle text that exceeds Slack's limits
+	limit := sectionTextLimit
+	if opts.simple {
+		limit = messageTextLimit
+	}
+	if opts.overflow != "" && utf8.RuneCountInString(text) > limit {
+		if opts.overflow == overflowFile {
+			return uploadOverflow(c, channelID, text, opts)
+		}
+		return sendSplit(c, channelID, text, limit, opts)
+	}
+
 	var blocks []interface{}
 	if blocksSource != "" {
 		if err := json.Unmarshal([]byte(blocksSource), &blocks); err != nil {
@@ -206,20 +376,71 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		}
 	} else if !opts.simple && text != "" {
 		// Default to block style for a more refined appearance
-		blocks = buildDefaultBlocks(text)
+		blocks = client.DefaultBlocks(text)
+	}
+
+	var journal *idempotencyJournal
+	if opts.idemKey != "" {
+		journal, err = loadIdempotencyJournal(idempotencyJournalPath(), time.Now())
+		if err != nil {
+			return err
+		}
+		existing, err := journal.claim(opts.idemKey, channelID, time.Now())
+		if err != nil {
+			return fmt.Errorf("writing idempotency journal: %w", err)
+		}
+		if existing != nil {
+			return resendIdempotent(c, *existing, channelID, text, blocks, metadata, opts)
+		}
+	}
+
+	if opts.upsertKey != "" {
+		existing, err := findUpsertTarget(c, channelID, opts.threadTS, opts.upsertKey)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if err := c.UpdateMessageWithMetadata(channelID, existing.TS, text, blocks, !opts.noUnfurl, metadata); err != nil {
+				return client.WrapError(fmt.Sprintf("update message %s", existing.TS), err)
+			}
+
+			return printSent(c, channelID, &client.Message{
+				Type:     "message",
+				User:     existing.User,
+				Text:     text,
+				TS:       existing.TS,
+				ThreadTS: existing.ThreadTS,
+				Metadata: metadata,
+			}, "Message updated (ts: %s)", opts.permalink)
+		}
 	}
 
-	msg, err := c.SendMessage(channelID, text, opts.threadTS, blocks, !opts.noUnfurl)
+	msg, err := c.SendMessageWithMetadata(channelID, text, opts.threadTS, blocks, !opts.noUnfurl, metadata)
 	if err != nil {
-		return client.WrapError("send message", err)
+		if journal != nil {
+			// Best effort: if this fails, the pending key expires on its own
+			_ = journal.release(opts.idemKey, time.Now())
+		}
+		return wrapSendError(err)
 	}
 
-	if output.IsJSON() {
-		return output.PrintJSON(msg)
+	if journal != nil {
+		if err := journal.record(opts.idemKey, channelID, msg.TS, time.Now(), opts.idemTTL); err != nil {
+			return fmt.Errorf("writing idempotency journal: %w", err)
+		}
 	}
 
-	output.Printf("Message sent (ts: %s)\n", msg.TS)
-	return nil
+	return printSent(c, channelID, msg, "Message sent (ts: %s)", opts.permalink)
+}
+
+// wrapSendError wraps a send error, pointing to --split and --overflow when
+// the text was too long; only messages send has those flags
+func wrapSendError(err error) error {
+	wrapped := client.WrapError("send message", err)
+	if strings.Contains(err.Error(), "msg_too_long") || strings.Contains(err.Error(), "invalid_blocks") {
+		return fmt.Errorf("%w\nUse --split to thread long text in parts or --overflow=file to upload it.", wrapped)
+	}
+	return wrapped
 }
 
 func uploadFiles(c *client.Client, channelID, text string, opts *sendOptions) error {
@@ -234,33 +455,23 @@ func uploadFiles(c *client.Client, channelID, text string, opts *sendOptions) er
 		filename := filepath.Base(filePath)
 		output.Printf("Uploading %s (%d bytes)...\n", filename, info.Size())
 
-		// Step 1: Get upload URL
-		uploadResp, err := c.GetUploadURLExternal(filename, info.Size())
-		if err != nil {
-			return client.WrapError("get upload URL", err)
-		}
-
-		// Step 2: Upload file bytes
 		f, err := os.Open(filePath)
 		if err != nil {
 			return fmt.Errorf("opening file %s: %w", filePath, err)
 		}
 
-		err = c.UploadFileToURL(uploadResp.UploadURL, f)
-		_ = f.Close()
-		if err != nil {
-			return client.WrapError("upload file", err)
-		}
-
 		title := opts.fileTitle
 		if title == "" {
 			title = filename
 		}
 
-		uploadedFiles = append(uploadedFiles, client.CompleteUploadExternalFile{
-			ID:    uploadResp.FileID,
-			Title: title,
-		})
+		file, err := uploadFile(c, filename, title, info.Size(), f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+
+		uploadedFiles = append(uploadedFiles, file)
 	}
 
 	// Step 3: Complete upload and share to channel/thread
@@ -277,3 +488,110 @@ func uploadFiles(c *client.Client, channelID, text string, opts *sendOptions) er
 
 	return nil
 }
+
+// resendIdempotent handles a send whose idempotency key was already used: it
+// reports the original message, or updates it with --update-existing
+func resendIdempotent(c *client.Client, entry idempotencyEntry, channelID, text string, blocks []interface{}, metadata *client.MessageMetadata, opts *sendOptions) error {
+	if entry.Channel != channelID {
+		return fmt.Errorf("idempotency key %q was already used for a message in channel %s", opts.idemKey, entry.Channel)
+	}
+	if entry.Pending {
+		return fmt.Errorf("a send with idempotency key %q is still in progress; try again once it finishes", opts.idemKey)
+	}
+
+	msg := &client.Message{Type: "message", TS: entry.TS, Text: text}
+	if opts.updateExist {
+		if err := c.UpdateMessageWithMetadata(channelID, entry.TS, text, blocks, !opts.noUnfurl, metadata); err != nil {
+			return client.WrapError(fmt.Sprintf("update message %s", entry.TS), err)
+		}
+		return printSent(c, channelID, msg, "Message updated (ts: %s)", opts.permalink)
+	}
+
+	return printSent(c, channelID, msg, "Message already sent (ts: %s)", opts.permalink)
+}
+
+// uploadFile performs the first two steps of an external upload: requesting an
+// upload URL and sending the file bytes. The caller completes the upload.
+func uploadFile(c *client.Client, filename, title string, size int64, data io.Reader) (client.CompleteUploadExternalFile, error) {
+	// Step 1: Get upload URL
+	uploadResp, err := c.GetUploadURLExternal(filename, size)
+	if err != nil {
+		return client.CompleteUploadExternalFile{}, client.WrapError("get upload URL", err)
+	}
+
+	// Step 2: Upload file bytes
+	if err := c.UploadFileToURL(uploadResp.UploadURL, data); err != nil {
+		return client.CompleteUploadExternalFile{}, client.WrapError("upload file", err)
+	}
+
+	return client.CompleteUploadExternalFile{
+		ID:    uploadResp.FileID,
+		Title: title,
+	}, nil
+}
+
+// sendSplit posts text that exceeds limit as several messages. The first part is
+// posted to the channel (or thread, if --thread is set) and the rest are threaded under it.
+func sendSplit(c *client.Client, channelID, text string, limit int, opts *sendOptions) error {
+	chunks := splitText(text, limit)
+
+	threadTS := opts.threadTS
+	sent := make([]*client.Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		var blocks []interface{}
+		if !opts.simple {
+			blocks = client.DefaultBlocks(chunk)
+		}
+
+		msg, err := c.SendMessage(channelID, chunk, threadTS, blocks, !opts.noUnfurl)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("send message part %d of %d", i+1, len(chunks)), err)
+		}
+		if threadTS == "" {
+			threadTS = msg.TS
+		}
+		sent = append(sent, msg)
+	}
+
+	if opts.permalink {
+		link, err := c.GetPermalink(channelID, sent[0].TS)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not get permalink for %s: %v\n", sent[0].TS, err)
+		}
+		sent[0].Permalink = link
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(sent)
+	}
+
+	output.Printf("Message sent in %d parts (ts: %s)\n", len(sent), sent[0].TS)
+	if sent[0].Permalink != "" {
+		output.Printf("  %s\n", sent[0].Permalink)
+	}
+	return nil
+}
+
+// uploadOverflow uploads text that exceeds Slack's message limit as a text file.
+func uploadOverflow(c *client.Client, channelID, text string, opts *sendOptions) error {
+	const filename = "message.txt"
+
+	title := opts.fileTitle
+	if title == "" {
+		title = filename
+	}
+
+	output.Printf("Message too long, uploading as %s (%d bytes)...\n", filename, len(text))
+
+	file, err := uploadFile(c, filename, title, int64(len(text)), strings.NewReader(text))
+	if err != nil {
+		return err
+	}
+
+	if err := c.CompleteUploadExternal([]client.CompleteUploadExternalFile{file}, channelID, opts.threadTS, ""); err != nil {
+		return client.WrapError("complete upload", err)
+	}
+
+	output.Printf("Message uploaded as file to channel %s\n", channelID)
+	return nil
+}
@@ -0,0 +1,130 @@
+package messages
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// sectionTextLimit is the maximum length of a Block Kit section's text field
+	sectionTextLimit = 3000
+	// messageTextLimit is the maximum length of a message's text field
+	messageTextLimit = 40000
+
+	codeFence = "```"
+)
+
+// Overflow modes for messages that exceed Slack's length limits
+const (
+	overflowSplit = "split"
+	overflowFile  = "file"
+)
+
+// splitText breaks text into chunks of at most limit characters.
+// Chunks are cut on paragraph breaks, code fence boundaries or line breaks where
+// possible. If a cut falls inside a code block, the fence is closed at the end of
+// the chunk and reopened at the start of the next one so each chunk renders correctly.
+func splitText(text string, limit int) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	reopen := codeFence + "\n"
+	closing := "\n" + codeFence
+
+	var chunks []string
+	inFence := false
+	rest := text
+	for rest != "" {
+		prefix := ""
+		if inFence {
+			prefix = reopen
+		}
+
+		if utf8.RuneCountInString(prefix+rest) <= limit {
+			chunks = append(chunks, prefix+rest)
+			break
+		}
+
+		// Reserve room for reopening and closing a fence around this chunk
+		cut := splitPoint(rest, limit-len(prefix)-len(closing))
+		part := strings.TrimRight(rest[:cut], "\n")
+		rest = strings.TrimLeft(rest[cut:], "\n")
+		if part == "" {
+			continue
+		}
+
+		chunk := prefix + part
+		inFence = strings.Count(chunk, codeFence)%2 == 1
+		if inFence {
+			chunk += closing
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// splitPoint returns the byte offset at which to cut s so that the first part holds
+// at most max characters. It prefers, in order: a blank line outside a code block,
+// the edge of a code block, a line break, and a space. Candidates in the first half
+// of the window are ignored to avoid producing many tiny chunks.
+func splitPoint(s string, max int) int {
+	end := runeOffset(s, max)
+	if end >= len(s) {
+		return len(s)
+	}
+
+	window := s[:end]
+	minCut := len(window) / 2
+
+	var para, fence, line int
+	inFence := false
+	for pos := 0; pos < len(window); {
+		nl := strings.IndexByte(window[pos:], '\n')
+		if nl < 0 {
+			break
+		}
+		current := window[pos : pos+nl]
+		if strings.HasPrefix(strings.TrimSpace(current), codeFence) && strings.Count(current, codeFence)%2 == 1 {
+			inFence = !inFence
+			if inFence {
+				fence = pos // before the opening fence
+			} else {
+				fence = pos + nl + 1 // after the closing fence
+			}
+		}
+		if nl == 0 && !inFence {
+			para = pos
+		}
+		line = pos + nl + 1
+		pos += nl + 1
+	}
+
+	for _, cut := range []int{para, fence, line} {
+		if cut > 0 && cut >= minCut {
+			return cut
+		}
+	}
+	if sp := strings.LastIndexByte(window, ' '); sp > 0 && sp >= minCut {
+		return sp + 1
+	}
+	if end == 0 {
+		// Always make progress, even with a degenerate limit
+		_, size := utf8.DecodeRuneInString(s)
+		return size
+	}
+	return end
+}
+
+// runeOffset returns the byte offset of the n-th rune in s, or len(s) if s is shorter.
+func runeOffset(s string, n int) int {
+	count := 0
+	for i := range s {
+		if count == n {
+			return i
+		}
+		count++
+	}
+	return len(s)
+}
@@ -0,0 +1,295 @@
+package messages
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+// streamTailLimit caps the characters of output shown in the live message
+const streamTailLimit = 3500
+
+type streamOptions struct {
+	title    string
+	threadTS string
+	lines    int
+	interval time.Duration
+	upload   bool
+	tee      bool
+	stdin    io.Reader // For testing
+}
+
+// streamResult is the JSON output of stream
+type streamResult struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+	Lines   int    `json:"lines"`
+	Status  string `json:"status"`
+	FileID  string `json:"file_id,omitempty"`
+}
+
+func newStreamCmd() *cobra.Command {
+	opts := &streamOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stream <channel>",
+		Short: "Stream stdin into a single live-updating message",
+		Long: `Post a message and keep it updated with the tail of stdin as lines arrive,
+instead of posting a message per line.
+
+Updates are debounced to at most one per --interval to stay within Slack's
+rate limits. When stdin closes, the message is finalized with a status header,
+and --upload attaches the full output as a file in the message's thread.
+
+Examples:
+  make build 2>&1 | slck messages stream builds --title "Build #42"
+  ./deploy.sh 2>&1 | slck messages stream deploys --upload --tee
+  tail -f app.log | slck messages stream ops --lines 10 --interval 5s`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runStream(ctx, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.title, "title", "Output", "Title shown in the message header")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Post the message as a reply in this thread")
+	cmd.Flags().IntVar(&opts.lines, "lines", 20, "Number of trailing lines to show")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "Minimum time between message updates")
+	cmd.Flags().BoolVar(&opts.upload, "upload", false, "Upload the full output as a file in the thread when done")
+	cmd.Flags().BoolVar(&opts.tee, "tee", false, "Also copy stdin to stdout")
+
+	return cmd
+}
+
+func runStream(ctx context.Context, channel string, opts *streamOptions, c *client.Client) error {
+	if opts.lines < 1 {
+		return fmt.Errorf("--lines must be at least 1")
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if opts.threadTS != "" {
+		if err := validate.Timestamp(opts.threadTS); err != nil {
+			return err
+		}
+		opts.threadTS = validate.NormalizeTimestamp(opts.threadTS)
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	s := &stream{title: opts.title, maxLines: opts.lines, started: time.Now(), keepFull: opts.upload}
+
+	msg, err := c.SendMessage(channelID, s.render(streamRunning), opts.threadTS, nil, false)
+	if err != nil {
+		return client.WrapError("post stream message", err)
+	}
+
+	reader := opts.stdin
+	if reader == nil {
+		reader = os.Stdin
+	}
+	// Stop the reader once the stream is done, however it ends
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- readLines(readCtx, reader, lines)
+		close(lines)
+	}()
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	var notBefore time.Time
+	dirty := false
+	status := streamFinished
+loop:
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if err := <-readErr; err != nil {
+					status = streamFailed
+				}
+				break loop
+			}
+			if opts.tee {
+				output.Println(line)
+			}
+			s.add(line)
+			dirty = true
+		case <-ticker.C:
+			if !dirty || time.Now().Before(notBefore) {
+				continue
+			}
+			err := c.UpdateMessage(channelID, msg.TS, s.render(streamRunning), nil, false)
+			if wait, ok := client.IsRateLimited(err); ok {
+				notBefore = time.Now().Add(wait)
+				continue
+			}
+			if err != nil {
+				return client.WrapError("update stream message", err)
+			}
+			dirty = false
+		case <-ctx.Done():
+			status = streamInterrupted
+			break loop
+		}
+	}
+
+	if err := finalizeStream(c, channelID, msg.TS, s.render(status)); err != nil {
+		return err
+	}
+
+	result := streamResult{Channel: channelID, TS: msg.TS, Lines: s.count, Status: status}
+	if opts.upload && s.count > 0 {
+		file, err := uploadFile(c, "output.log", opts.title, int64(s.full.Len()), bytes.NewReader(s.full.Bytes()))
+		if err != nil {
+			return err
+		}
+		if err := c.CompleteUploadExternal([]client.CompleteUploadExternalFile{file}, channelID, threadRoot(msg.TS, opts.threadTS), ""); err != nil {
+			return client.WrapError("complete upload", err)
+		}
+		result.FileID = file.ID
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(result)
+	}
+	output.Printf("Streamed %d lines to message %s (%s)\n", s.count, msg.TS, status)
+	return nil
+}
+
+// finalizeStream writes the final message, waiting out a rate limit once if needed
+func finalizeStream(c *client.Client, channelID, ts, text string) error {
+	err := c.UpdateMessage(channelID, ts, text, nil, false)
+	if wait, ok := client.IsRateLimited(err); ok {
+		time.Sleep(wait)
+		err = c.UpdateMessage(channelID, ts, text, nil, false)
+	}
+	if err != nil {
+		return client.WrapError("finalize stream message", err)
+	}
+	return nil
+}
+
+// threadRoot returns the thread a file should be shared into: the stream's own
+// thread, or the thread the stream message was posted in
+func threadRoot(ts, threadTS string) string {
+	if threadTS != "" {
+		return threadTS
+	}
+	return ts
+}
+
+// readLines sends each line read from r to lines, without trailing newlines,
+// until r ends or ctx is cancelled
+func readLines(ctx context.Context, r io.Reader, lines chan<- string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case lines <- scanner.Text():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return scanner.Err()
+}
+
+// Stream statuses shown in the message header
+const (
+	streamRunning     = "running"
+	streamFinished    = "finished"
+	streamInterrupted = "interrupted"
+	streamFailed      = "failed"
+)
+
+// stream accumulates streamed output and renders the live message
+type stream struct {
+	title    string
+	maxLines int
+	started  time.Time
+	tail     []string
+	count    int
+
+	// keepFull keeps every line in full for --upload; otherwise only the
+	// tail is kept, so a long-running pipe doesn't grow without bound
+	keepFull bool
+	full     bytes.Buffer
+}
+
+func (s *stream) add(line string) {
+	if s.keepFull {
+		s.full.WriteString(line)
+		s.full.WriteByte('\n')
+	}
+	s.count++
+
+	s.tail = append(s.tail, line)
+	if len(s.tail) > s.maxLines {
+		s.tail = s.tail[len(s.tail)-s.maxLines:]
+	}
+}
+
+// render builds the message text for the given status
+func (s *stream) render(status string) string {
+	elapsed := time.Since(s.started).Round(time.Second)
+
+	var header string
+	switch status {
+	case streamRunning:
+		header = fmt.Sprintf(":hourglass_flowing_sand: *%s* running for %s (%d lines)", s.title, elapsed, s.count)
+	case streamFinished:
+		header = fmt.Sprintf(":white_check_mark: *%s* finished in %s (%d lines)", s.title, elapsed, s.count)
+	case streamInterrupted:
+		header = fmt.Sprintf(":warning: *%s* interrupted after %s (%d lines)", s.title, elapsed, s.count)
+	default:
+		header = fmt.Sprintf(":x: *%s* failed reading output after %s (%d lines)", s.title, elapsed, s.count)
+	}
+
+	body := strings.Join(s.tail, "\n")
+	// Keep the output from closing the code block early
+	body = strings.ReplaceAll(body, codeFence, "'''")
+	if n := len(body); n > streamTailLimit {
+		cut := n - streamTailLimit
+		for cut < n && !utf8.RuneStart(body[cut]) {
+			cut++
+		}
+		body = "…" + body[cut:]
+	}
+	if body == "" {
+		body = "(no output)"
+	}
+
+	return header + "\n" + codeFence + "\n" + body + "\n" + codeFence
+}
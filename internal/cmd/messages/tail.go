@@ -0,0 +1,268 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// maxTrackedThreads caps how many threads per channel are polled for new replies
+const maxTrackedThreads = 20
+
+type tailOptions struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	replies     bool
+	backlog     int
+	raw         bool
+}
+
+// tailEvent is a single message printed by tail in JSON mode
+type tailEvent struct {
+	Channel  string `json:"channel"`
+	UserName string `json:"user_name,omitempty"`
+	client.Message
+}
+
+// MarshalJSON flattens the message's fields alongside channel and user_name
+func (e tailEvent) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{"channel": e.Channel}
+	if e.UserName != "" {
+		fields["user_name"] = e.UserName
+	}
+	return client.MarshalWithFields(e.Message, fields)
+}
+
+// tailedChannel tracks the polling state of one channel
+type tailedChannel struct {
+	label   string
+	id      string
+	oldest  string
+	threads []*tailedThread
+}
+
+// tailedThread tracks the newest reply seen in a thread
+type tailedThread struct {
+	ts     string
+	oldest string
+}
+
+func newTailCmd() *cobra.Command {
+	opts := &tailOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tail <channel>...",
+		Short: "Follow new messages in one or more channels",
+		Long: `Follow new messages in one or more channels, printing them as they arrive.
+
+Polls conversations.history for messages newer than the last one seen. The
+polling interval starts at --interval and backs off towards --max-interval
+while channels are quiet, resetting as soon as new messages arrive. Rate
+limit responses from Slack are honoured automatically.
+
+Use --replies to also follow replies to messages posted while tailing.
+With -o json, each message is printed as one JSON object per line (NDJSON).
+
+Press Ctrl-C to stop.
+
+Examples:
+  slck messages tail deploys
+  slck messages tail deploys alerts --replies
+  slck messages tail C1234567890 --backlog 10
+  slck messages tail deploys -o json | jq -r .text`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runTail(ctx, args, opts, nil)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.interval, "interval", 3*time.Second, "Minimum time between polls")
+	cmd.Flags().DurationVar(&opts.maxInterval, "max-interval", 30*time.Second, "Maximum time between polls when channels are quiet")
+	cmd.Flags().BoolVar(&opts.replies, "replies", false, "Also show new thread replies")
+	cmd.Flags().IntVar(&opts.backlog, "backlog", 0, "Number of recent messages to show before following")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+
+	return cmd
+}
+
+func runTail(ctx context.Context, channels []string, opts *tailOptions, c *client.Client) error {
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if opts.maxInterval < opts.interval {
+		opts.maxInterval = opts.interval
+	}
+	if opts.backlog < 0 {
+		return fmt.Errorf("--backlog cannot be negative")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	start := client.TimestampFromTime(time.Now())
+	tailed := make([]*tailedChannel, 0, len(channels))
+	for _, channel := range channels {
+		channelID, err := c.ResolveChannel(channel)
+		if err != nil {
+			return err
+		}
+		tailed = append(tailed, &tailedChannel{
+			label:  strings.TrimPrefix(channel, "#"),
+			id:     channelID,
+			oldest: start,
+		})
+	}
+
+	resolver := client.NewUserResolver(c)
+	printer := &tailPrinter{
+		resolver:  resolver,
+		renderer:  mrkdwn.NewRenderer(c, resolver),
+		showLabel: len(tailed) > 1,
+		raw:       opts.raw,
+	}
+
+	if opts.backlog > 0 {
+		for _, ch := range tailed {
+			messages, err := c.GetChannelHistory(ch.id, opts.backlog, "", "")
+			if err != nil {
+				return client.WrapError(fmt.Sprintf("get history for %s", ch.label), err)
+			}
+			for _, m := range client.Chronological(messages) {
+				if err := printer.print(ch, m, false); err != nil {
+					return err
+				}
+				// Only follow replies posted from now on
+				ch.track(m.TS, start)
+			}
+		}
+	}
+
+	interval := opts.interval
+	for {
+		count, err := pollChannels(c, tailed, printer, opts.replies)
+		if wait, ok := client.IsRateLimited(err); ok {
+			interval = minDuration(interval*2, opts.maxInterval)
+			if !client.SleepContext(ctx, wait) {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			interval = opts.interval
+		} else {
+			interval = minDuration(interval*2, opts.maxInterval)
+		}
+
+		if !client.SleepContext(ctx, interval) {
+			return nil
+		}
+	}
+}
+
+// pollChannels fetches and prints new messages for every channel, returning how many were printed
+func pollChannels(c *client.Client, tailed []*tailedChannel, printer *tailPrinter, replies bool) (int, error) {
+	count := 0
+	for _, ch := range tailed {
+		messages, err := c.GetChannelHistorySince(ch.id, ch.oldest)
+		if err != nil {
+			return count, client.WrapError(fmt.Sprintf("get history for %s", ch.label), err)
+		}
+		for _, m := range client.Chronological(messages) {
+			if err := printer.print(ch, m, false); err != nil {
+				return count, err
+			}
+			ch.oldest = m.TS
+			ch.track(m.TS, m.TS)
+			count++
+		}
+
+		if !replies {
+			continue
+		}
+		for _, thread := range ch.threads {
+			msgs, err := c.GetThreadRepliesSince(ch.id, thread.ts, thread.oldest, 200)
+			if err != nil {
+				return count, client.WrapError(fmt.Sprintf("get replies for %s", ch.label), err)
+			}
+			for _, m := range client.Chronological(msgs) {
+				// Slack always returns the parent; skip it and anything already seen
+				if m.TS == thread.ts || client.CompareTimestamps(m.TS, thread.oldest) <= 0 {
+					continue
+				}
+				if err := printer.print(ch, m, true); err != nil {
+					return count, err
+				}
+				thread.oldest = m.TS
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// track starts following replies to the message at ts, dropping the oldest
+// thread once maxTrackedThreads is reached
+func (ch *tailedChannel) track(ts, oldest string) {
+	ch.threads = append(ch.threads, &tailedThread{ts: ts, oldest: oldest})
+	if len(ch.threads) > maxTrackedThreads {
+		ch.threads = ch.threads[len(ch.threads)-maxTrackedThreads:]
+	}
+}
+
+// tailPrinter prints tailed messages as text lines or NDJSON
+type tailPrinter struct {
+	resolver  *client.UserResolver
+	renderer  *mrkdwn.Renderer
+	showLabel bool
+	raw       bool
+}
+
+func (p *tailPrinter) print(ch *tailedChannel, m client.Message, reply bool) error {
+	name := p.resolver.Author(m)
+
+	if output.IsJSON() {
+		return output.PrintJSONLine(tailEvent{
+			Channel:  ch.id,
+			UserName: name,
+			Message:  m,
+		})
+	}
+
+	var prefix string
+	if p.showLabel {
+		prefix = "#" + ch.label + " "
+	}
+	if reply {
+		prefix += "↳ "
+	}
+	output.Printf("[%s] %s%s: %s\n", formatTimestamp(m.TS), prefix, name, messageBody(p.renderer, m, p.raw))
+	return nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -1,35 +1,71 @@
 package messages
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/transcript"
 	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
 
 type threadOptions struct {
-	limit int
+	limit     int
+	raw       bool
+	permalink bool
+	format    string
 }
 
 func newThreadCmd() *cobra.Command {
 	opts := &threadOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "thread <channel> <thread-ts>",
+		Use:   "thread <channel> <thread-ts> | thread <permalink>",
 		Short: "Get thread replies",
-		Args:  cobra.ExactArgs(2),
+		Long: `Get the replies in a thread.
+
+The thread can be given as a channel and the parent's timestamp, or as a
+permalink to the parent or to any reply in the thread.
+
+With --format md the thread is printed as a Markdown document, ready to
+paste into a postmortem or doc. See also: slck transcript.
+
+Examples:
+  slck messages thread general 1700000000.000100
+  slck messages thread https://example.slack.com/archives/C123/p1700000000000100 --format md > thread.md`,
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runThread(args[0], args[1], opts, nil)
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the thread", rest[0])
+			}
+			threadTS := ref.TS
+			if ref.ThreadTS != "" {
+				threadTS = ref.ThreadTS
+			}
+			return runThread(ref.Channel, threadTS, opts, nil)
 		},
 	}
 
 	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum replies to return")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "Include each message's permalink (one API call per uncached message)")
+	cmd.Flags().StringVar(&opts.format, "format", "text", "Text format: text, or md for a Markdown document")
 
 	return cmd
 }
 
 func runThread(channel, threadTS string, opts *threadOptions, c *client.Client) error {
+	if opts.format != "" && opts.format != "text" && opts.format != "md" {
+		return fmt.Errorf("invalid format %q: must be text or md", opts.format)
+	}
+
 	// Normalize thread timestamp (accepts API format, p-prefixed, or full URL)
 	threadTS = validate.NormalizeTimestamp(threadTS)
 
@@ -52,6 +88,12 @@ func runThread(channel, threadTS string, opts *threadOptions, c *client.Client)
 		return err
 	}
 
+	if opts.permalink {
+		if err := addPermalinks(c, channelID, messages); err != nil {
+			return err
+		}
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(messages)
 	}
@@ -62,11 +104,26 @@ func runThread(channel, threadTS string, opts *threadOptions, c *client.Client)
 	}
 
 	resolver := client.NewUserResolver(c)
+	renderer := mrkdwn.NewRenderer(c, resolver)
+
+	if opts.format == "md" {
+		t := &transcript.Transcript{
+			Title:    "Thread in #" + client.NewChannelResolver(c).Resolve(channelID),
+			Threads:  []transcript.Thread{transcript.NewThread(messages)},
+			Users:    resolver,
+			Renderer: renderer,
+		}
+		return t.WriteMarkdown(output.Writer)
+	}
+
 	for _, m := range messages {
 		ts := formatTimestamp(m.TS)
-		text := truncate(resolver.ResolveMentions(m.Text), 80)
-		name := resolver.Resolve(m.User)
+		text := messageBody(renderer, m, opts.raw)
+		name := resolver.Author(m)
 		output.Printf("[%s] %s: %s\n", ts, name, text)
+		if m.Permalink != "" {
+			output.Printf("  %s\n", m.Permalink)
+		}
 	}
 
 	return nil
@@ -16,11 +16,18 @@ func newUnreactCmd() *cobra.Command {
 	opts := &unreactOptions{}
 
 	return &cobra.Command{
-		Use:   "unreact <channel> <timestamp> <emoji>",
+		Use:   "unreact <channel> <timestamp> <emoji> | unreact <permalink> <emoji>",
 		Short: "Remove a reaction from a message",
-		Args:  cobra.ExactArgs(3),
+		Args:  cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUnreact(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) != 1 {
+				return fmt.Errorf("expected an emoji after the message")
+			}
+			return runUnreact(ref.Channel, ref.TS, rest[0], opts, nil)
 		},
 	}
 }
@@ -30,6 +37,7 @@ func runUnreact(channel, timestamp, emoji string, opts *unreactOptions, c *clien
 	if err := validate.Timestamp(timestamp); err != nil {
 		return err
 	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
 
 	// Normalize emoji (remove colons)
 	emoji = validate.Emoji(emoji)
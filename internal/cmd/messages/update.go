@@ -8,38 +8,82 @@ import (
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
 
 type updateOptions struct {
 	blocksJSON string
 	simple     bool
 	noUnfurl   bool
+	edit       bool
+	mentions   bool
+	unresolved string
+	editor     func(path string) error // For testing
 }
 
 func newUpdateCmd() *cobra.Command {
 	opts := &updateOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "update <channel> <timestamp> <text>",
+		Use:   "update <channel> <timestamp> [text] | update <permalink> [text]",
 		Short: "Update an existing message",
 		Long: `Update an existing message.
 
 By default, messages are updated using Slack Block Kit formatting for a more
-refined appearance. Use --simple to update with plain text instead.`,
-		Args: cobra.ExactArgs(3),
+refined appearance. Use --simple to update with plain text instead.
+
+Use --edit instead of text to open the message's current text in $EDITOR
+(or $VISUAL) and post the saved version. Saving an empty or unchanged message
+aborts:
+  slck messages update announcements 1234567890.123456 --edit
+
+The message can also be given as a permalink:
+  slck messages update https://workspace.slack.com/archives/C1234567890/p1234567890123456 "Fixed typo"`,
+		Args: cobra.RangeArgs(1, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 1 {
+				return fmt.Errorf("unexpected argument %q after the message text", rest[1])
+			}
+			text := ""
+			if len(rest) > 0 {
+				text = rest[0]
+			}
+			return runUpdate(ref.Channel, ref.TS, text, opts, nil)
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Block Kit blocks as JSON array (overrides default block formatting)")
 	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Update as plain text without block formatting")
 	cmd.Flags().BoolVar(&opts.noUnfurl, "no-unfurl", false, "Disable link preview unfurling")
+	cmd.Flags().BoolVar(&opts.edit, "edit", false, "Edit the message's current text in $EDITOR")
+	cmd.Flags().BoolVar(&opts.mentions, "resolve-mentions", false, "Turn @user, @group, #channel and emails into Slack mentions")
+	cmd.Flags().StringVar(&opts.unresolved, "unresolved", unresolvedFail, "What to do with mentions that can't be resolved: fail or warn")
 
 	return cmd
 }
 
 func runUpdate(channel, timestamp, text string, opts *updateOptions, c *client.Client) error {
+	if opts.mentions {
+		if err := validateUnresolvedMode(opts.unresolved); err != nil {
+			return err
+		}
+	}
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if opts.edit && text != "" {
+		return fmt.Errorf("cannot pass text with --edit")
+	}
+	if !opts.edit && text == "" {
+		return fmt.Errorf("message text cannot be empty (or use --edit)")
+	}
+
 	// Unescape shell-escaped characters (e.g., \! from zsh)
 	text = unescapeShellChars(text)
 
@@ -57,6 +101,24 @@ func runUpdate(channel, timestamp, text string, opts *updateOptions, c *client.C
 		return err
 	}
 
+	if opts.edit {
+		msg, err := c.GetMessage(channelID, timestamp)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get message %s", timestamp), err)
+		}
+		text, err = composeInEditor(msg.Text, opts.editor)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.mentions {
+		text, err = encodeMentions(c, text, opts.unresolved)
+		if err != nil {
+			return err
+		}
+	}
+
 	var blocks []interface{}
 	if opts.blocksJSON != "" {
 		if err := json.Unmarshal([]byte(opts.blocksJSON), &blocks); err != nil {
@@ -64,7 +126,7 @@ func runUpdate(channel, timestamp, text string, opts *updateOptions, c *client.C
 		}
 	} else if !opts.simple {
 		// Default to block style for a more refined appearance
-		blocks = buildDefaultBlocks(text)
+		blocks = client.DefaultBlocks(text)
 	}
 
 	if err := c.UpdateMessage(channelID, timestamp, text, blocks, !opts.noUnfurl); err != nil {
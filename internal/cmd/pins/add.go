@@ -0,0 +1,62 @@
+package pins
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type addOptions struct{}
+
+func newAddCmd() *cobra.Command {
+	opts := &addOptions{}
+
+	return &cobra.Command{
+		Use:   "add <channel> <timestamp> | add <permalink>",
+		Short: "Pin a message to a channel",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the message", rest[0])
+			}
+			return runAdd(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+}
+
+func runAdd(channel, timestamp string, opts *addOptions, c *client.Client) error {
+	// Validate and normalize timestamp (accepts API format, p-prefixed, or full URL)
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	if err := c.AddPin(channelID, timestamp); err != nil {
+		return client.WrapError(fmt.Sprintf("pin message %s", timestamp), err)
+	}
+
+	output.Println("Message pinned")
+	return nil
+}
@@ -0,0 +1,109 @@
+package pins
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type listOptions struct {
+	raw bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list <channel>",
+		Short: "List items pinned to a channel",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+
+	return cmd
+}
+
+func runList(channel string, opts *listOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	items, err := c.ListPins(channelID)
+	if err != nil {
+		return client.WrapError("list pins", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(items)
+	}
+
+	if len(items) == 0 {
+		output.Println("No pinned items")
+		return nil
+	}
+
+	resolver := client.NewUserResolver(c)
+	render := func(text string) string { return text }
+	if !opts.raw {
+		renderer := mrkdwn.NewRenderer(c, resolver)
+		renderer.Color = false // Text is truncated, which would cut styles apart
+		render = renderer.Render
+	}
+
+	if output.IsTable() {
+		headers := []string{"POSTED", "AUTHOR", "TEXT", "PERMALINK"}
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			posted, author, text, link := describeItem(item, resolver, render)
+			rows = append(rows, []string{posted, author, output.Truncate(text, 50), link})
+		}
+		output.Table(headers, rows)
+		return nil
+	}
+
+	for _, item := range items {
+		posted, author, text, link := describeItem(item, resolver, render)
+		output.Printf("[%s] %s: %s\n", posted, author, output.Truncate(text, 80))
+		if link != "" {
+			output.Printf("  %s\n", link)
+		}
+	}
+
+	return nil
+}
+
+// describeItem returns the posted time, resolved author, text and permalink of a pinned item
+func describeItem(item client.PinnedItem, resolver *client.UserResolver, render func(string) string) (posted, author, text, link string) {
+	switch {
+	case item.Message != nil:
+		m := item.Message
+		return client.FormatTimestamp(m.TS), resolver.Resolve(m.User), render(m.Text), m.Permalink
+	case item.File != nil:
+		f := item.File
+		title := f.Title
+		if title == "" {
+			title = f.Name
+		}
+		return time.Unix(f.Created, 0).Format("2006-01-02 15:04"), resolver.Resolve(f.User), "[file] " + title, f.Permalink
+	default:
+		return time.Unix(item.Created, 0).Format("2006-01-02 15:04"), resolver.Resolve(item.CreatedBy), "[" + item.Type + "]", ""
+	}
+}
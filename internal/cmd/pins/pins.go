@@ -0,0 +1,17 @@
+package pins
+
+import "github.com/spf13/cobra"
+
+// NewCmd creates the pins command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pins",
+		Short: "Manage pinned messages",
+	}
+
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newRemoveCmd())
+	cmd.AddCommand(newListCmd())
+
+	return cmd
+}
@@ -0,0 +1,202 @@
+package pins
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func TestRunAdd_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pins.add", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "C123", body["channel"])
+		assert.Equal(t, "1234567890.123456", body["timestamp"])
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runAdd("C123", "1234567890.123456", &addOptions{}, c)
+	require.NoError(t, err)
+}
+
+func TestRunAdd_AcceptsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "1234567890.123456", body["timestamp"])
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runAdd("C123", "https://example.slack.com/archives/C123/p1234567890123456", &addOptions{}, c)
+	require.NoError(t, err)
+}
+
+func TestRunAdd_InvalidTimestamp(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	err := runAdd("C123", "not-a-timestamp", &addOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timestamp")
+}
+
+func TestRunRemove_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pins.remove", r.URL.Path)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runRemove("C123", "1234567890.123456", &removeOptions{}, c)
+	require.NoError(t, err)
+}
+
+func TestRunRemove_NotPinned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "no_pin"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runRemove("C123", "1234567890.123456", &removeOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not pinned")
+}
+
+func pinsHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pins.list":
+			assert.Equal(t, "C123", r.URL.Query().Get("channel"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"items": []map[string]interface{}{
+					{
+						"type":    "message",
+						"channel": "C123",
+						"message": map[string]interface{}{
+							"ts":        "1234567890.123456",
+							"user":      "U001",
+							"text":      "Runbook for <@U002>",
+							"permalink": "https://example.slack.com/archives/C123/p1234567890123456",
+						},
+					},
+				},
+			})
+		case "/users.info":
+			names := map[string]string{"U001": "alice", "U002": "bob"}
+			id := r.URL.Query().Get("user")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": id, "name": names[id]},
+			})
+		}
+	}
+}
+
+func TestRunList_Text(t *testing.T) {
+	server := httptest.NewServer(pinsHandler(t))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runList("C123", &listOptions{}, c)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "alice: Runbook for @bob")
+	assert.Contains(t, buf.String(), "https://example.slack.com/archives/C123/p1234567890123456")
+}
+
+func TestRunList_Raw(t *testing.T) {
+	server := httptest.NewServer(pinsHandler(t))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runList("C123", &listOptions{raw: true}, c)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "alice: Runbook for <@U002>")
+}
+
+func TestRunList_Table(t *testing.T) {
+	server := httptest.NewServer(pinsHandler(t))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatTable
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runList("C123", &listOptions{}, c)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "PERMALINK")
+	assert.Contains(t, buf.String(), "alice")
+}
+
+func TestRunList_JSON(t *testing.T) {
+	server := httptest.NewServer(pinsHandler(t))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runList("C123", &listOptions{}, c)
+	require.NoError(t, err)
+
+	var items []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, "message", items[0]["type"])
+}
+
+func TestRunList_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "items": []interface{}{}})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runList("C123", &listOptions{}, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "No pinned items")
+}
@@ -0,0 +1,107 @@
+package poll
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type createOptions struct {
+	options []string
+}
+
+// createdPoll is the JSON output of poll create
+type createdPoll struct {
+	Channel  string       `json:"channel"`
+	TS       string       `json:"ts"`
+	Question string       `json:"question"`
+	Options  []pollOption `json:"options"`
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <channel> <question> --option <text>...",
+		Short: "Post a poll",
+		Long: `Post a poll with numbered options and seed a reaction for each option,
+so voters only need to click.
+
+Between 2 and 10 options are supported. Use 'slck poll results' to tally votes.
+
+Examples:
+  slck poll create general "Lunch on Friday?" --option Pizza --option Tacos --option Sushi
+  slck poll create team "Retro format" --option "Start/Stop/Continue" --option "4Ls"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.options, "option", nil, "Poll option (repeat for each option)")
+
+	return cmd
+}
+
+func runCreate(channel, question string, opts *createOptions, c *client.Client) error {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return fmt.Errorf("question cannot be empty")
+	}
+	if len(opts.options) < 2 {
+		return fmt.Errorf("at least 2 --option values are required")
+	}
+	if len(opts.options) > len(optionEmoji) {
+		return fmt.Errorf("at most %d options are supported", len(optionEmoji))
+	}
+
+	options := make([]pollOption, 0, len(opts.options))
+	for i, text := range opts.options {
+		text = strings.Join(strings.Fields(text), " ")
+		if text == "" {
+			return fmt.Errorf("option %d cannot be empty", i+1)
+		}
+		options = append(options, pollOption{Emoji: optionEmoji[i], Text: text})
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	msg, err := c.SendMessage(channelID, formatPoll(question, options, nil, ""), "", nil, false)
+	if err != nil {
+		return client.WrapError("post poll", err)
+	}
+
+	for _, opt := range options {
+		if err := c.AddReaction(channelID, msg.TS, opt.Emoji); err != nil {
+			return client.WrapError(fmt.Sprintf("seed reaction :%s:", opt.Emoji), err)
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(createdPoll{
+			Channel:  channelID,
+			TS:       msg.TS,
+			Question: question,
+			Options:  options,
+		})
+	}
+
+	output.Printf("Poll posted (ts: %s)\n", msg.TS)
+	return nil
+}
@@ -0,0 +1,86 @@
+package poll
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// optionEmoji are the reactions used to vote for each option, in order
+var optionEmoji = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "keycap_ten"}
+
+const (
+	pollHeader = ":bar_chart: "
+	// resultSeparator divides an option from its tally when results are edited into the poll
+	resultSeparator = "  ·  "
+)
+
+// NewCmd creates the poll command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "poll",
+		Short: "Run reaction-based polls",
+	}
+
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newResultsCmd())
+
+	return cmd
+}
+
+// pollOption is one numbered choice in a poll
+type pollOption struct {
+	Emoji string `json:"emoji"`
+	Text  string `json:"text"`
+}
+
+// formatPoll renders a poll message. If tallies is non-nil, each option line
+// is followed by its tally and the footer replaces the voting hint.
+func formatPoll(question string, options []pollOption, tallies []string, footer string) string {
+	var b strings.Builder
+	b.WriteString(pollHeader + "*" + question + "*\n\n")
+	for i, opt := range options {
+		b.WriteString(":" + opt.Emoji + ": " + opt.Text)
+		if tallies != nil {
+			b.WriteString(resultSeparator + tallies[i])
+		}
+		b.WriteString("\n")
+	}
+	if footer == "" {
+		footer = "_React with a number to vote_"
+	}
+	b.WriteString("\n" + footer)
+	return b.String()
+}
+
+// parsePoll extracts the question and options from a poll message created by formatPoll
+func parsePoll(text string) (string, []pollOption, error) {
+	// Slack returns &, < and > escaped in message text
+	text = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">").Replace(text)
+
+	lines := strings.Split(text, "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], pollHeader) {
+		return "", nil, fmt.Errorf("message is not a poll created by 'slck poll create'")
+	}
+	question := strings.Trim(strings.TrimPrefix(lines[0], pollHeader), "*")
+
+	var options []pollOption
+	for _, line := range lines[1:] {
+		if len(options) == len(optionEmoji) {
+			break
+		}
+		emoji := optionEmoji[len(options)]
+		prefix := ":" + emoji + ": "
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		text, _, _ := strings.Cut(strings.TrimPrefix(line, prefix), resultSeparator)
+		options = append(options, pollOption{Emoji: emoji, Text: text})
+	}
+	if len(options) == 0 {
+		return "", nil, fmt.Errorf("no poll options found in message")
+	}
+
+	return question, options, nil
+}
@@ -0,0 +1,156 @@
+package poll
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func TestFormatAndParsePoll(t *testing.T) {
+	options := []pollOption{{Emoji: "one", Text: "Pizza & pasta"}, {Emoji: "two", Text: "Tacos"}}
+
+	text := formatPoll("Lunch?", options, nil, "")
+	assert.Equal(t, ":bar_chart: *Lunch?*\n\n:one: Pizza & pasta\n:two: Tacos\n\n_React with a number to vote_", text)
+
+	// Slack escapes & in returned text, and results may have been edited in
+	edited := formatPoll("Lunch?", options, []string{"*2 votes*", "*0 votes*"}, "_2 votes_")
+	edited = strings.ReplaceAll(edited, "&", "&amp;")
+
+	question, parsed, err := parsePoll(edited)
+	require.NoError(t, err)
+	assert.Equal(t, "Lunch?", question)
+	assert.Equal(t, options, parsed)
+}
+
+func TestParsePoll_NotAPoll(t *testing.T) {
+	_, _, err := parsePoll("Just a message")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a poll")
+}
+
+func TestRunCreate_SeedsReactions(t *testing.T) {
+	var reactions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			assert.Equal(t, "C123", body["channel"])
+			assert.Contains(t, body["text"], ":two: Tacos")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+		case "/reactions.add":
+			assert.Equal(t, "1234567890.123456", body["timestamp"])
+			reactions = append(reactions, body["name"].(string))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &createOptions{options: []string{"Pizza", "Tacos", "Sushi"}}
+
+	err := runCreate("C123", "Lunch?", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, reactions)
+	assert.Contains(t, buf.String(), "1234567890.123456")
+}
+
+func TestRunCreate_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		wantErr string
+	}{
+		{"too few", []string{"A"}, "at least 2"},
+		{"too many", strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","), "at most 10"},
+		{"empty option", []string{"A", " "}, "option 2 cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runCreate("C123", "Question?", &createOptions{options: tt.options}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunResults_ExcludesSeedReactions(t *testing.T) {
+	var updated, posted map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reactions.get":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"message": map[string]interface{}{
+					"ts":   "1234567890.123456",
+					"text": ":bar_chart: *Lunch?*\n\n:one: Pizza\n:two: Tacos\n\n_React with a number to vote_",
+					"reactions": []map[string]interface{}{
+						{"name": "one", "count": 3, "users": []string{"UBOT", "U001", "U002"}},
+						{"name": "two", "count": 1, "users": []string{"UBOT"}},
+						{"name": "eyes", "count": 1, "users": []string{"U003"}},
+					},
+				},
+			})
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/users.info":
+			id := r.URL.Query().Get("user")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": id, "name": strings.ToLower(id)},
+			})
+		case "/chat.update":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/chat.postMessage":
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567891.000000"})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &resultsOptions{post: true, update: true}
+
+	err := runResults("C123", "1234567890.123456", opts, c)
+	require.NoError(t, err)
+
+	var got pollResults
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "Lunch?", got.Question)
+	assert.Equal(t, 2, got.TotalVotes)
+	require.Len(t, got.Options, 2)
+	assert.Equal(t, 2, got.Options[0].Votes)
+	assert.Equal(t, []string{"U001", "U002"}, got.Options[0].Voters)
+	assert.Equal(t, 0, got.Options[1].Votes)
+
+	require.NotNil(t, updated)
+	assert.Contains(t, updated["text"], ":one: Pizza  ·  *2 votes* (u001, u002)")
+	require.NotNil(t, posted)
+	assert.Equal(t, "1234567890.123456", posted["thread_ts"])
+	assert.Contains(t, posted["text"], "Results: Lunch?")
+}
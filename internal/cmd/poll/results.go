@@ -0,0 +1,208 @@
+package poll
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type resultsOptions struct {
+	post   bool
+	update bool
+}
+
+// optionResult is the tally for one poll option
+type optionResult struct {
+	pollOption
+	Votes      int      `json:"votes"`
+	Voters     []string `json:"voters"`
+	VoterNames []string `json:"voter_names"`
+}
+
+// pollResults is the JSON output of poll results
+type pollResults struct {
+	Channel    string         `json:"channel"`
+	TS         string         `json:"ts"`
+	Question   string         `json:"question"`
+	Options    []optionResult `json:"options"`
+	TotalVotes int            `json:"total_votes"`
+}
+
+func newResultsCmd() *cobra.Command {
+	opts := &resultsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "results <channel> <timestamp> | results <permalink>",
+		Short: "Tally the votes on a poll",
+		Long: `Tally the votes on a poll created with 'slck poll create'.
+
+The reactions seeded when the poll was created are not counted. Use --post to
+reply to the poll's thread with the results, or --update to edit the results
+into the poll message itself.
+
+Examples:
+  slck poll results general 1234567890.123456
+  slck poll results general 1234567890.123456 --post
+  slck poll results https://workspace.slack.com/archives/C1234567890/p1234567890123456 --update`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, rest, err := validate.MessageArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(rest) > 0 {
+				return fmt.Errorf("unexpected argument %q after the poll", rest[0])
+			}
+			return runResults(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.post, "post", false, "Post the results as a thread reply")
+	cmd.Flags().BoolVar(&opts.update, "update", false, "Edit the results into the poll message")
+
+	return cmd
+}
+
+func runResults(channel, timestamp string, opts *resultsOptions, c *client.Client) error {
+	// Validate and normalize timestamp (accepts API format, p-prefixed, or full URL)
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve channel name to ID if needed
+	channelID, err := c.ResolveChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	msg, err := c.GetReactions(channelID, timestamp)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get poll %s", timestamp), err)
+	}
+
+	question, options, err := parsePoll(msg.Text)
+	if err != nil {
+		return err
+	}
+
+	// The poster's seed reactions are not votes
+	auth, err := c.AuthTest()
+	if err != nil {
+		return client.WrapError("identify current user", err)
+	}
+
+	results := tally(options, msg.Reactions, auth.UserID, client.NewUserResolver(c))
+	total := 0
+	for _, r := range results {
+		total += r.Votes
+	}
+
+	if opts.update {
+		tallies := make([]string, len(results))
+		for i, r := range results {
+			tallies[i] = formatTally(r)
+		}
+		footer := fmt.Sprintf("_%s as of %s_", votesLabel(total), time.Now().Format("2006-01-02 15:04"))
+		if err := c.UpdateMessage(channelID, timestamp, formatPoll(question, options, tallies, footer), nil, false); err != nil {
+			return client.WrapError("update poll", err)
+		}
+	}
+
+	if opts.post {
+		if _, err := c.SendMessage(channelID, formatResults(question, results, total), timestamp, nil, false); err != nil {
+			return client.WrapError("post poll results", err)
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(pollResults{
+			Channel:    channelID,
+			TS:         timestamp,
+			Question:   question,
+			Options:    results,
+			TotalVotes: total,
+		})
+	}
+
+	if output.IsTable() {
+		headers := []string{"OPTION", "VOTES", "VOTERS"}
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			rows = append(rows, []string{":" + r.Emoji + ": " + r.Text, fmt.Sprintf("%d", r.Votes), strings.Join(r.VoterNames, ", ")})
+		}
+		output.Table(headers, rows)
+		return nil
+	}
+
+	output.Println(question)
+	for _, r := range results {
+		output.Printf("  :%s: %s  %s\n", r.Emoji, r.Text, formatTally(r))
+	}
+	output.Println(votesLabel(total))
+	return nil
+}
+
+// tally counts the votes for each option, ignoring reactions by exclude
+func tally(options []pollOption, reactions []client.Reaction, exclude string, resolver *client.UserResolver) []optionResult {
+	byName := make(map[string]client.Reaction, len(reactions))
+	for _, r := range reactions {
+		byName[r.Name] = r
+	}
+
+	results := make([]optionResult, 0, len(options))
+	for _, opt := range options {
+		result := optionResult{pollOption: opt, Voters: []string{}, VoterNames: []string{}}
+		for _, u := range byName[opt.Emoji].Users {
+			if u == exclude {
+				continue
+			}
+			result.Voters = append(result.Voters, u)
+			result.VoterNames = append(result.VoterNames, resolver.Resolve(u))
+		}
+		result.Votes = len(result.Voters)
+		results = append(results, result)
+	}
+	return results
+}
+
+// formatResults renders the results as a thread reply
+func formatResults(question string, results []optionResult, total int) string {
+	var b strings.Builder
+	b.WriteString(":bar_chart: *Results: " + question + "*\n\n")
+	for _, r := range results {
+		b.WriteString(":" + r.Emoji + ": " + r.Text + resultSeparator + formatTally(r) + "\n")
+	}
+	b.WriteString("\n_" + votesLabel(total) + "_")
+	return b.String()
+}
+
+// formatTally renders an option's vote count and voters
+func formatTally(r optionResult) string {
+	s := "*" + votesLabel(r.Votes) + "*"
+	if len(r.VoterNames) > 0 {
+		s += " (" + strings.Join(r.VoterNames, ", ") + ")"
+	}
+	return s
+}
+
+func votesLabel(n int) string {
+	if n == 1 {
+		return "1 vote"
+	}
+	return fmt.Sprintf("%d votes", n)
+}
@@ -0,0 +1,167 @@
+package reactions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type listOptions struct {
+	user  string
+	limit int
+	raw   bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List items a user has reacted to",
+		Long: `List the messages and files a user has reacted to, most recent first,
+with the emoji they used.
+
+Examples:
+  slck reactions list
+  slck reactions list --user @alice --limit 50
+  slck reactions list --user U01234ABCDE -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", "User whose reactions to list (ID, @handle or email; default: you)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum items to return")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+
+	return cmd
+}
+
+func runList(opts *listOptions, c *client.Client) error {
+	if err := validate.Limit(opts.limit); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	var userID string
+	if opts.user != "" {
+		var err error
+		userID, err = c.ResolveUser(opts.user)
+		if err != nil {
+			return err
+		}
+	} else {
+		auth, err := c.AuthTest()
+		if err != nil {
+			return client.WrapError("identify current user", err)
+		}
+		userID = auth.UserID
+	}
+
+	items, err := c.ListReactions(userID, opts.limit)
+	if err != nil {
+		return client.WrapError("list reactions", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(items)
+	}
+
+	if len(items) == 0 {
+		output.Println("No reactions found")
+		return nil
+	}
+
+	resolver := client.NewUserResolver(c)
+	render := func(text string) string { return text }
+	if !opts.raw {
+		renderer := mrkdwn.NewRenderer(c, resolver)
+		renderer.Color = false // Text is truncated, which would cut styles apart
+		render = renderer.Render
+	}
+	channels := client.NewChannelResolver(c)
+
+	if output.IsTable() {
+		headers := []string{"POSTED", "CHANNEL", "AUTHOR", "REACTIONS", "TEXT"}
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			posted, author, text, _ := describeItem(item, resolver, render)
+			rows = append(rows, []string{posted, channelName(channels, item.Channel), author, userEmoji(item, userID), output.Truncate(text, 50)})
+		}
+		output.Table(headers, rows)
+		return nil
+	}
+
+	for _, item := range items {
+		posted, author, text, link := describeItem(item, resolver, render)
+		where := ""
+		if item.Channel != "" {
+			where = channelName(channels, item.Channel) + " "
+		}
+		output.Printf("[%s] %s%s: %s  %s\n", posted, where, author, output.Truncate(text, 80), userEmoji(item, userID))
+		if link != "" {
+			output.Printf("  %s\n", link)
+		}
+	}
+
+	return nil
+}
+
+// describeItem returns the posted time, resolved author, text and permalink of a reacted item
+func describeItem(item client.ReactedItem, resolver *client.UserResolver, render func(string) string) (posted, author, text, link string) {
+	switch {
+	case item.Message != nil:
+		m := item.Message
+		return client.FormatTimestamp(m.TS), resolver.Resolve(m.User), render(m.Text), m.Permalink
+	case item.File != nil:
+		f := item.File
+		title := f.Title
+		if title == "" {
+			title = f.Name
+		}
+		return time.Unix(f.Created, 0).Format("2006-01-02 15:04"), resolver.Resolve(f.User), "[file] " + title, f.Permalink
+	default:
+		return "", "", "[" + item.Type + "]", ""
+	}
+}
+
+// userEmoji returns the reactions the user added to a message, as :name: codes
+func userEmoji(item client.ReactedItem, userID string) string {
+	if item.Message == nil {
+		return ""
+	}
+
+	var names []string
+	for _, r := range item.Message.Reactions {
+		for _, u := range r.Users {
+			if u == userID {
+				names = append(names, ":"+r.Name+":")
+				break
+			}
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// channelName returns #name for a channel ID, falling back to the ID
+func channelName(resolver *client.ChannelResolver, channelID string) string {
+	name := resolver.Resolve(channelID)
+	if name == channelID {
+		return name
+	}
+	return "#" + name
+}
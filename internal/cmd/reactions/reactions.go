@@ -0,0 +1,15 @@
+package reactions
+
+import "github.com/spf13/cobra"
+
+// NewCmd creates the reactions command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reactions",
+		Short: "Inspect reactions",
+	}
+
+	cmd.AddCommand(newListCmd())
+
+	return cmd
+}
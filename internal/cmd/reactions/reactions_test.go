@@ -0,0 +1,106 @@
+package reactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func reactionsServer(t *testing.T, wantUser string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "U999"})
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"members": []map[string]interface{}{{"id": "U001", "name": "alice"}},
+			})
+		case "/reactions.list":
+			assert.Equal(t, wantUser, r.URL.Query().Get("user"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"items": []map[string]interface{}{
+					{
+						"type":    "message",
+						"channel": "C123",
+						"message": map[string]interface{}{
+							"ts":        "1234567890.123456",
+							"user":      "U002",
+							"text":      "Deployed v1.2",
+							"permalink": "https://workspace.slack.com/archives/C123/p1234567890123456",
+							"reactions": []map[string]interface{}{
+								{"name": "tada", "count": 2, "users": []string{wantUser, "U003"}},
+								{"name": "eyes", "count": 1, "users": []string{"U003"}},
+							},
+						},
+					},
+				},
+			})
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "deploys"},
+			})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": "U002", "name": "bob", "profile": map[string]interface{}{"display_name": "bob"}},
+			})
+		}
+	}))
+}
+
+func TestRunList_ForUser(t *testing.T) {
+	server := reactionsServer(t, "U001")
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runList(&listOptions{user: "@alice", limit: 20}, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "#deploys bob: Deployed v1.2  :tada:")
+	assert.NotContains(t, buf.String(), ":eyes:")
+	assert.Contains(t, buf.String(), "https://workspace.slack.com/archives/C123/p1234567890123456")
+}
+
+func TestRunList_DefaultsToCurrentUser(t *testing.T) {
+	server := reactionsServer(t, "U999")
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runList(&listOptions{limit: 20}, c)
+	require.NoError(t, err)
+
+	var got []client.ReactedItem
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "C123", got[0].Channel)
+}
+
+func TestRunList_InvalidLimit(t *testing.T) {
+	err := runList(&listOptions{limit: 0}, nil)
+	require.Error(t, err)
+}
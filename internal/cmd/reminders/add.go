@@ -0,0 +1,93 @@
+package reminders
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+)
+
+type addOptions struct {
+	time string
+	user string
+	now  func() time.Time // For testing
+}
+
+func newAddCmd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <text> --time <when>",
+		Short: "Create a reminder",
+		Long: `Create a reminder for yourself or someone else.
+
+Time formats:
+` + timeparse.Syntax + `
+
+Examples:
+  slck reminders add "Stand-up" --time "in 15 minutes"
+  slck reminders add "Hand over on-call" --time "tomorrow 9am" --user @alice
+  slck reminders add "Renew certificate" --time 2025-06-01T09:00:00Z`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.time, "time", "", "When to send the reminder (required)")
+	cmd.Flags().StringVar(&opts.user, "user", "", "User to remind (ID, @handle or email; default: you)")
+
+	return cmd
+}
+
+func runAdd(text string, opts *addOptions, c *client.Client) error {
+	if text == "" {
+		return fmt.Errorf("reminder text cannot be empty")
+	}
+	if opts.time == "" {
+		return fmt.Errorf("--time is required")
+	}
+
+	now := time.Now()
+	if opts.now != nil {
+		now = opts.now()
+	}
+	at, err := timeparse.Parse(opts.time, now)
+	if err != nil {
+		return err
+	}
+	if !at.After(now) {
+		return fmt.Errorf("reminder time %s is in the past", at.Format("2006-01-02 15:04"))
+	}
+
+	if c == nil {
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	var userID string
+	if opts.user != "" {
+		userID, err = c.ResolveUser(opts.user)
+		if err != nil {
+			return err
+		}
+	}
+
+	reminder, err := c.AddReminder(text, at.Unix(), userID)
+	if err != nil {
+		return client.WrapError("add reminder", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(reminder)
+	}
+
+	output.Printf("Reminder set for %s (%s)\n", at.Format("2006-01-02 15:04"), reminder.ID)
+	return nil
+}
@@ -0,0 +1,42 @@
+package reminders
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type completeOptions struct{}
+
+func newCompleteCmd() *cobra.Command {
+	opts := &completeOptions{}
+
+	return &cobra.Command{
+		Use:   "complete <reminder-id>",
+		Short: "Mark a reminder as complete",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runComplete(args[0], opts, nil)
+		},
+	}
+}
+
+func runComplete(reminderID string, opts *completeOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.CompleteReminder(reminderID); err != nil {
+		return client.WrapError(fmt.Sprintf("complete reminder %s", reminderID), err)
+	}
+
+	output.Println("Reminder completed")
+	return nil
+}
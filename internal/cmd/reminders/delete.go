@@ -0,0 +1,80 @@
+package reminders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type deleteOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newDeleteCmd() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <reminder-id>",
+		Short: "Delete a reminder",
+		Long: `Delete a reminder.
+
+You are asked to confirm unless --force is given. If nothing answers the
+prompt, as in a script or CI job, the reminder is not deleted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(reminderID string, opts *deleteOptions, c *client.Client) error {
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to delete reminder %s\n", reminderID)
+		output.Printf("Are you sure? [y/N]: ")
+
+		// No answer (such as stdin closed in CI) never goes ahead
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println()
+			return fmt.Errorf("no answer to the confirmation prompt; use --force to delete without confirming")
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.DeleteReminder(reminderID); err != nil {
+		return client.WrapError(fmt.Sprintf("delete reminder %s", reminderID), err)
+	}
+
+	output.Println("Reminder deleted")
+	return nil
+}
@@ -0,0 +1,88 @@
+package reminders
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type listOptions struct {
+	all bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List your reminders",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include completed reminders")
+
+	return cmd
+}
+
+func runList(opts *listOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	reminders, err := c.ListReminders()
+	if err != nil {
+		return client.WrapError("list reminders", err)
+	}
+
+	filtered := make([]client.Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		if opts.all || r.CompleteTS == 0 {
+			filtered = append(filtered, r)
+		}
+	}
+	// Recurring reminders have no time; list them last
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i].Time, filtered[j].Time
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+
+	if output.IsJSON() {
+		return output.PrintJSON(filtered)
+	}
+
+	if len(filtered) == 0 {
+		output.Println("No reminders")
+		return nil
+	}
+
+	resolver := client.NewUserResolver(c)
+
+	headers := []string{"ID", "TIME", "USER", "STATUS", "TEXT"}
+	rows := make([][]string, 0, len(filtered))
+	for _, r := range filtered {
+		when := formatTime(r.Time)
+		if r.Recurring {
+			when = "recurring"
+		}
+		status := "pending"
+		if r.CompleteTS != 0 {
+			status = "done"
+		}
+		rows = append(rows, []string{r.ID, when, resolver.Resolve(r.User), status, output.Truncate(r.Text, 50)})
+	}
+	output.Table(headers, rows)
+	return nil
+}
@@ -0,0 +1,33 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the reminders command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reminders",
+		Short: "Manage reminders (requires user token)",
+		Long: `Create, list, complete and delete Slack reminders.
+
+Reminders belong to a person, so these commands always use your user token.`,
+	}
+
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newCompleteCmd())
+	cmd.AddCommand(newDeleteCmd())
+
+	return cmd
+}
+
+// formatTime converts a Unix time to a human-readable format
+func formatTime(sec int64) string {
+	if sec == 0 {
+		return ""
+	}
+	return time.Unix(sec, 0).Format("2006-01-02 15:04")
+}
@@ -0,0 +1,173 @@
+package reminders
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func fixedNow() time.Time {
+	return time.Date(2025, 6, 1, 14, 0, 0, 0, time.UTC)
+}
+
+func TestRunAdd_RelativeTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reminders.add", r.URL.Path)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Hand over on-call", body["text"])
+		assert.Equal(t, "1748793600", body["time"]) // 16:00 UTC
+		assert.NotContains(t, body, "user")
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":       true,
+			"reminder": map[string]interface{}{"id": "Rm001", "text": "Hand over on-call", "time": 1748793600},
+		})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &addOptions{time: "in 2 hours", now: fixedNow}
+
+	err := runAdd("Hand over on-call", opts, c)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Rm001")
+}
+
+func TestRunAdd_ForUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"members": []map[string]interface{}{{"id": "U111", "name": "alice"}},
+			})
+		case "/reminders.add":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "U111", body["user"])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":       true,
+				"reminder": map[string]interface{}{"id": "Rm002"},
+			})
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &addOptions{time: "tomorrow 9am", user: "@alice", now: fixedNow}
+
+	err := runAdd("Check the deploy", opts, c)
+	require.NoError(t, err)
+}
+
+func TestRunAdd_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *addOptions
+		wantErr string
+	}{
+		{"missing time", &addOptions{}, "--time is required"},
+		{"invalid time", &addOptions{time: "whenever"}, "invalid time"},
+		{"past time", &addOptions{time: "2 hours ago", now: fixedNow}, "in the past"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runAdd("text", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunList_HidesCompleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reminders.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"reminders": []map[string]interface{}{
+					{"id": "Rm002", "text": "Later", "time": 1748800000},
+					{"id": "Rm001", "text": "Sooner", "time": 1748790000},
+					{"id": "Rm003", "text": "Done", "time": 1748700000, "complete_ts": 1748700100},
+				},
+			})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runList(&listOptions{}, c)
+	require.NoError(t, err)
+
+	var got []client.Reminder
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "Rm001", got[0].ID)
+	assert.Equal(t, "Rm002", got[1].ID)
+}
+
+func TestRunDelete_Cancelled(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	opts := &deleteOptions{stdin: strings.NewReader("n\n")}
+
+	err := runDelete("Rm001", opts, nil)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Cancelled")
+}
+
+func TestRunDelete_NoAnswerDoesNotDelete(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	opts := &deleteOptions{stdin: strings.NewReader("")}
+
+	err := runDelete("Rm001", opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+}
+
+func TestRunComplete_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reminders.complete", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runComplete("Rm001", &completeOptions{}, c)
+	require.NoError(t, err)
+}
@@ -1,20 +1,35 @@
 package root
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/ask"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/channels"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/config"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/export"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/importcmd"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/initcmd"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/local"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/messages"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/pins"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/poll"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/reactions"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/reminders"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/search"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/sent"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/synccmd"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/transcript"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/users"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/watch"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/whoami"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/workspace"
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/version"
 )
@@ -57,13 +72,26 @@ Or set the SLACK_API_TOKEN environment variable.`,
 
 		return nil
 	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		// Looked-up permalinks are only a cache; failing to save them isn't an error
+		if err := client.SavePermalinkCache(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save permalink cache: %v\n", err)
+		}
+	},
 }
 
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+
+		// Commands like ask report their outcome through the exit code
+		code := 1
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) {
+			code = coded.ExitCode()
+		}
+		os.Exit(code)
 	}
 }
 
@@ -73,6 +101,16 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&asUser, "as-user", false, "Use user token")
 	rootCmd.PersistentFlags().BoolVar(&asBot, "as-bot", false, "Use bot token")
 
+	// Record sends in the opt-in sent journal. A send that succeeded must not
+	// fail because the journal couldn't be written.
+	client.SetSentHook(func(item client.SentItem) {
+		if err := sent.Record(item); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not record sent item: %v\n", err)
+		}
+	})
+
+	client.SetPermalinkCacheFile(filepath.Join(keychain.ConfigDir(), "permalinks.json"))
+
 	// Set custom version template to include commit and build date
 	rootCmd.SetVersionTemplate("slck " + version.Info() + "\n")
 
@@ -80,9 +118,22 @@ func init() {
 	rootCmd.AddCommand(channels.NewCmd())
 	rootCmd.AddCommand(users.NewCmd())
 	rootCmd.AddCommand(messages.NewCmd())
+	rootCmd.AddCommand(pins.NewCmd())
+	rootCmd.AddCommand(poll.NewCmd())
+	rootCmd.AddCommand(reactions.NewCmd())
+	rootCmd.AddCommand(reminders.NewCmd())
 	rootCmd.AddCommand(search.NewCmd())
 	rootCmd.AddCommand(workspace.NewCmd())
 	rootCmd.AddCommand(whoami.NewCmd())
+	rootCmd.AddCommand(watch.NewCmd())
+	rootCmd.AddCommand(ask.NewCmd())
+	rootCmd.AddCommand(sent.NewCmd())
+	rootCmd.AddCommand(sent.NewUndoCmd())
+	rootCmd.AddCommand(export.NewCmd())
+	rootCmd.AddCommand(importcmd.NewCmd())
+	rootCmd.AddCommand(transcript.NewCmd())
+	rootCmd.AddCommand(synccmd.NewCmd())
+	rootCmd.AddCommand(local.NewCmd())
 	rootCmd.AddCommand(config.NewCmd())
 	rootCmd.AddCommand(initcmd.NewCmd())
 }
@@ -21,6 +21,8 @@ type allOptions struct {
 	hasLink     bool
 	hasReaction bool
 	includeBots bool
+	raw         bool
+	permalink   bool
 }
 
 func newAllCmd() *cobra.Command {
@@ -58,6 +60,8 @@ Examples:
 	cmd.Flags().StringVarP(&opts.sort, "sort", "s", "score", "Sort by: score or timestamp")
 	cmd.Flags().StringVar(&opts.sortDir, "sort-dir", "desc", "Sort direction: asc or desc")
 	cmd.Flags().BoolVar(&opts.highlight, "highlight", false, "Highlight matching terms in results")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "Include a permalink column in text output")
 
 	// Query builder flags
 	cmd.Flags().StringVar(&opts.scope, "scope", "", "Search scope: all, public, private, dm, mpim")
@@ -106,6 +110,12 @@ func runSearchAll(query string, opts *allOptions, c *client.Client) error {
 		return err
 	}
 
+	if opts.permalink && result.Messages != nil {
+		if err := fillPermalinks(c, result.Messages.Matches); err != nil {
+			return err
+		}
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(result)
 	}
@@ -123,11 +133,19 @@ func runSearchAll(query string, opts *allOptions, c *client.Client) error {
 		output.Printf("=== Messages (%d total) ===\n\n", result.Messages.Total)
 
 		headers := []string{"CHANNEL", "USER", "TIMESTAMP", "TEXT"}
+		if opts.permalink {
+			headers = append(headers, "PERMALINK")
+		}
+		render := textRenderer(c, opts.raw)
 		rows := make([][]string, 0, len(result.Messages.Matches))
 		for _, m := range result.Messages.Matches {
-			text := truncateText(m.Text, 60)
+			text := truncateText(render(m.Text), 60)
 			ts := formatTimestamp(m.TS)
-			rows = append(rows, []string{m.Channel.Name, m.Username, ts, text})
+			row := []string{m.Channel.Name, m.Username, ts, text}
+			if opts.permalink {
+				row = append(row, m.Permalink)
+			}
+			rows = append(rows, row)
 		}
 		output.Table(headers, rows)
 
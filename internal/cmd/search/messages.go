@@ -5,10 +5,12 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -26,6 +28,8 @@ type messagesOptions struct {
 	hasLink     bool
 	hasReaction bool
 	includeBots bool
+	raw         bool
+	permalink   bool
 }
 
 func newMessagesCmd() *cobra.Command {
@@ -66,6 +70,8 @@ Examples:
 	cmd.Flags().StringVarP(&opts.sort, "sort", "s", "score", "Sort by: score or timestamp")
 	cmd.Flags().StringVar(&opts.sortDir, "sort-dir", "desc", "Sort direction: asc or desc")
 	cmd.Flags().BoolVar(&opts.highlight, "highlight", false, "Highlight matching terms in results")
+	cmd.Flags().BoolVar(&opts.raw, "raw", false, "Show message text exactly as Slack sends it (no mrkdwn rendering)")
+	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "Include a permalink column in text output")
 
 	// Query builder flags
 	cmd.Flags().StringVar(&opts.scope, "scope", "", "Search scope: all, public, private, dm, mpim")
@@ -114,6 +120,12 @@ func runSearchMessages(query string, opts *messagesOptions, c *client.Client) er
 		return err
 	}
 
+	if opts.permalink && result.Messages != nil {
+		if err := fillPermalinks(c, result.Messages.Matches); err != nil {
+			return err
+		}
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(result)
 	}
@@ -127,11 +139,19 @@ func runSearchMessages(query string, opts *messagesOptions, c *client.Client) er
 	output.Printf("Found %d messages matching \"%s\"\n\n", result.Messages.Total, query)
 
 	headers := []string{"CHANNEL", "USER", "TIMESTAMP", "TEXT"}
+	if opts.permalink {
+		headers = append(headers, "PERMALINK")
+	}
+	render := textRenderer(c, opts.raw)
 	rows := make([][]string, 0, len(result.Messages.Matches))
 	for _, m := range result.Messages.Matches {
-		text := truncateText(m.Text, 60)
+		text := truncateText(render(m.Text), 60)
 		ts := formatTimestamp(m.TS)
-		rows = append(rows, []string{m.Channel.Name, m.Username, ts, text})
+		row := []string{m.Channel.Name, m.Username, ts, text}
+		if opts.permalink {
+			row = append(row, m.Permalink)
+		}
+		rows = append(rows, row)
 	}
 	output.Table(headers, rows)
 
@@ -142,6 +162,32 @@ func runSearchMessages(query string, opts *messagesOptions, c *client.Client) er
 	return nil
 }
 
+// fillPermalinks looks up permalinks for matches that Slack returned without one
+func fillPermalinks(c *client.Client, matches []client.SearchMatch) error {
+	for i := range matches {
+		if matches[i].Permalink != "" {
+			continue
+		}
+		link, err := c.GetPermalink(matches[i].Channel.ID, matches[i].TS)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get permalink for %s", matches[i].TS), err)
+		}
+		matches[i].Permalink = link
+	}
+	return nil
+}
+
+// textRenderer returns a function that renders message text from mrkdwn for
+// table cells, or leaves it as sent with --raw
+func textRenderer(c *client.Client, raw bool) func(string) string {
+	if raw {
+		return func(text string) string { return text }
+	}
+	renderer := mrkdwn.NewRenderer(c, client.NewUserResolver(c))
+	renderer.Color = false // Table cells are truncated and padded
+	return renderer.Render
+}
+
 func validateSearchOptions(count, page int, sort, sortDir string) error {
 	if count < 1 || count > 100 {
 		return fmt.Errorf("count must be between 1 and 100")
@@ -163,10 +209,10 @@ func truncateText(s string, maxLen int) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	s = strings.ReplaceAll(s, "\r", "")
 
-	if len(s) <= maxLen {
+	if utf8.RuneCountInString(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+	return string([]rune(s)[:maxLen-3]) + "..."
 }
 
 func formatTimestamp(ts string) string {
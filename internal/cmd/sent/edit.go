@@ -0,0 +1,106 @@
+package sent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type editOptions struct {
+	blocksJSON string
+	simple     bool
+	noUnfurl   bool
+	path       string // For testing
+}
+
+func newEditCmd() *cobra.Command {
+	opts := &editOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit <n> <text>",
+		Short: "Change the text of a sent message",
+		Long: `Change the text of a message from the sent journal.
+
+<n> is the entry number from "slck sent list" (1 is the most recent). The
+message is updated with the same token it was sent with.
+
+Examples:
+  slck sent edit 1 "Deploy finished (corrected)"
+  slck sent edit 3 "Plain text" --simple`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Block Kit blocks as JSON array (overrides default block formatting)")
+	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Update as plain text without block formatting")
+	cmd.Flags().BoolVar(&opts.noUnfurl, "no-unfurl", false, "Disable link preview unfurling")
+
+	return cmd
+}
+
+func runEdit(arg, text string, opts *editOptions, c *client.Client) error {
+	n, err := parseCount(arg)
+	if err != nil {
+		return err
+	}
+
+	path := opts.path
+	if path == "" {
+		path = journalPath()
+	}
+	j, err := loadJournal(path)
+	if err != nil {
+		return err
+	}
+
+	entries := j.recent(n)
+	if len(entries) < n {
+		return fmt.Errorf("no sent entry %d (the journal has %d)", n, len(j.Entries))
+	}
+	e := entries[n-1]
+	if e.Kind != "message" {
+		return fmt.Errorf("entry %d is a file and cannot be edited", n)
+	}
+
+	var blocks []interface{}
+	if opts.blocksJSON != "" {
+		if err := json.Unmarshal([]byte(opts.blocksJSON), &blocks); err != nil {
+			return fmt.Errorf("invalid blocks JSON: %w", err)
+		}
+	} else if !opts.simple {
+		// Match the block formatting messages send uses by default
+		blocks = client.DefaultBlocks(text)
+	}
+
+	if c == nil {
+		c, err = clientFor(e, map[bool]*client.Client{})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.UpdateMessage(e.Channel, e.TS, text, blocks, !opts.noUnfurl); err != nil {
+		return client.WrapError(fmt.Sprintf("update %s", describe(e)), err)
+	}
+
+	// Keep the fingerprint in step with what's now in Slack
+	err = j.update(func() {
+		for i := range j.Entries {
+			if j.Entries[i].Channel == e.Channel && j.Entries[i].TS == e.TS {
+				j.Entries[i].TextHash = hashText(text)
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("writing sent journal: %w", err)
+	}
+
+	output.Printf("Message updated (ts: %s)\n", e.TS)
+	return nil
+}
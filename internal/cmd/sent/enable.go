@@ -0,0 +1,46 @@
+package sent
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func newEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable",
+		Short: "Start recording sent messages and files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setEnabled(journalPath(), true)
+		},
+	}
+}
+
+func newDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop recording sent messages and files",
+		Long: `Stop recording sent messages and files.
+
+Existing entries are kept, so "slck undo" still works for them.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setEnabled(journalPath(), false)
+		},
+	}
+}
+
+func setEnabled(path string, enabled bool) error {
+	j := &journal{path: path}
+	if err := j.update(func() { j.Enabled = enabled }); err != nil {
+		return err
+	}
+
+	if enabled {
+		output.Printf("Sent journal enabled (%s)\n", path)
+	} else {
+		output.Println("Sent journal disabled")
+	}
+	return nil
+}
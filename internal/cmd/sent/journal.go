@@ -0,0 +1,170 @@
+package sent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/filelock"
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+)
+
+// maxEntries caps the journal so it doesn't grow without bound
+const maxEntries = 1000
+
+// entry records one message or file sent by slck
+type entry struct {
+	Kind     string    `json:"kind"` // "message" or "file"
+	Channel  string    `json:"channel"`
+	TS       string    `json:"ts,omitempty"`
+	FileID   string    `json:"file_id,omitempty"`
+	TextHash string    `json:"text_hash,omitempty"`
+	AsUser   bool      `json:"as_user,omitempty"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// journal is the opt-in log of everything slck has sent. Entries are kept
+// oldest first.
+type journal struct {
+	path    string
+	Enabled bool    `json:"enabled"`
+	Entries []entry `json:"entries"`
+}
+
+// journalPath returns the journal location under the config directory
+func journalPath() string {
+	return filepath.Join(keychain.ConfigDir(), "sent.json")
+}
+
+// loadJournal reads the journal. A missing journal is treated as empty and
+// disabled.
+func loadJournal(path string) (*journal, error) {
+	j := &journal{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sent journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("parsing sent journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// save writes the journal atomically
+func (j *journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// update applies change to the journal and saves it. The journal is read
+// again under a lock first, so entries recorded by other slck processes in
+// the meantime, such as parallel sends, aren't lost.
+func (j *journal) update(change func()) error {
+	unlock, err := filelock.Lock(j.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	latest, err := loadJournal(j.path)
+	if err != nil {
+		return err
+	}
+	j.Enabled, j.Entries = latest.Enabled, latest.Entries
+	change()
+	return j.save()
+}
+
+// add appends an entry, dropping the oldest ones past maxEntries
+func (j *journal) add(e entry) {
+	j.Entries = append(j.Entries, e)
+	if len(j.Entries) > maxEntries {
+		j.Entries = j.Entries[len(j.Entries)-maxEntries:]
+	}
+}
+
+// recent returns up to n entries, most recent first
+func (j *journal) recent(n int) []entry {
+	if n > len(j.Entries) {
+		n = len(j.Entries)
+	}
+	result := make([]entry, 0, n)
+	for i := len(j.Entries) - 1; i >= len(j.Entries)-n; i-- {
+		result = append(result, j.Entries[i])
+	}
+	return result
+}
+
+// remove drops the entry for the same message or file as e
+func (j *journal) remove(e entry) {
+	kept := j.Entries[:0]
+	for _, existing := range j.Entries {
+		if existing.Channel == e.Channel && existing.TS == e.TS && existing.FileID == e.FileID {
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	j.Entries = kept
+}
+
+// hashText returns a short fingerprint of a message's text, so the journal
+// can tell messages apart without storing their content
+func hashText(text string) string {
+	if text == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Record adds a sent message or file to the journal if the journal is enabled
+func Record(item client.SentItem) error {
+	j, err := loadJournal(journalPath())
+	if err != nil {
+		return err
+	}
+	if !j.Enabled {
+		return nil
+	}
+
+	e := entry{
+		Kind:     "message",
+		Channel:  item.Channel,
+		TS:       item.TS,
+		TextHash: hashText(item.Text),
+		AsUser:   item.AsUser,
+		SentAt:   time.Now().UTC(),
+	}
+	if item.FileID != "" {
+		e.Kind = "file"
+		e.FileID = item.FileID
+	}
+
+	return j.update(func() {
+		// The journal may have been disabled since it was first read
+		if j.Enabled {
+			j.add(e)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package sent
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type listOptions struct {
+	limit int
+	path  string // For testing
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recently sent messages and files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum number of entries to show")
+
+	return cmd
+}
+
+// listedEntry is an entry with its number, for JSON output
+type listedEntry struct {
+	N int `json:"n"`
+	entry
+}
+
+func runList(opts *listOptions) error {
+	if opts.limit < 1 {
+		return fmt.Errorf("--limit must be positive")
+	}
+
+	path := opts.path
+	if path == "" {
+		path = journalPath()
+	}
+	j, err := loadJournal(path)
+	if err != nil {
+		return err
+	}
+
+	entries := j.recent(opts.limit)
+
+	if output.IsJSON() {
+		listed := make([]listedEntry, 0, len(entries))
+		for i, e := range entries {
+			listed = append(listed, listedEntry{N: i + 1, entry: e})
+		}
+		return output.PrintJSON(listed)
+	}
+
+	if !j.Enabled {
+		output.Println("The sent journal is disabled; turn it on with: slck sent enable")
+	}
+	if len(entries) == 0 {
+		output.Println("No sent messages recorded")
+		return nil
+	}
+
+	headers := []string{"#", "SENT", "KIND", "CHANNEL", "TS/FILE", "TEXT HASH"}
+	rows := make([][]string, 0, len(entries))
+	for i, e := range entries {
+		ref := e.TS
+		if e.Kind == "file" {
+			ref = e.FileID
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			e.SentAt.Local().Format("2006-01-02 15:04:05"),
+			e.Kind,
+			e.Channel,
+			ref,
+			e.TextHash,
+		})
+	}
+	output.Table(headers, rows)
+	return nil
+}
@@ -0,0 +1,70 @@
+package sent
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// NewCmd creates the sent command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sent",
+		Short: "Review messages and files sent by slck",
+		Long: `Keep a local journal of every message and file slck sends.
+
+The journal is off by default. Turn it on with "slck sent enable"; from then on
+each send records the channel, timestamp, a hash of the text and the time.
+Use "slck undo" to delete recent sends and "slck sent edit" to change one.
+
+Entries are numbered from the most recent: 1 is the last thing sent.`,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newEditCmd())
+	cmd.AddCommand(newEnableCmd())
+	cmd.AddCommand(newDisableCmd())
+
+	return cmd
+}
+
+// parseCount parses a positive entry number or count argument
+func parseCount(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid number %q: must be a positive integer", arg)
+	}
+	return n, nil
+}
+
+// clientFor returns a client using the token the entry was sent with, so
+// edits and deletes are made by the same identity
+func clientFor(e entry, clients map[bool]*client.Client) (*client.Client, error) {
+	if c, ok := clients[e.AsUser]; ok {
+		return c, nil
+	}
+
+	var c *client.Client
+	var err error
+	if e.AsUser {
+		c, err = client.NewUserClient()
+	} else {
+		c, err = client.NewBotClient()
+	}
+	if err != nil {
+		return nil, err
+	}
+	clients[e.AsUser] = c
+	return c, nil
+}
+
+// describe returns a short label for an entry
+func describe(e entry) string {
+	if e.Kind == "file" {
+		return fmt.Sprintf("file %s in %s", e.FileID, e.Channel)
+	}
+	return fmt.Sprintf("message %s in %s", e.TS, e.Channel)
+}
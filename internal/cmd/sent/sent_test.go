@@ -0,0 +1,252 @@
+package sent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// writeJournal creates a journal in a temp dir with the given entries, oldest first
+func writeJournal(t *testing.T, entries ...entry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sent.json")
+	j := &journal{path: path, Enabled: true, Entries: entries}
+	require.NoError(t, j.save())
+	return path
+}
+
+func messageEntry(channel, ts string) entry {
+	return entry{Kind: "message", Channel: channel, TS: ts, TextHash: hashText("text " + ts), SentAt: time.Now()}
+}
+
+func TestRecord(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// Disabled by default: nothing is written
+	require.NoError(t, Record(client.SentItem{Channel: "C123", TS: "1.0", Text: "hi"}))
+	_, err := os.Stat(journalPath())
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, setEnabled(journalPath(), true))
+	require.NoError(t, Record(client.SentItem{Channel: "C123", TS: "1.0", Text: "hi"}))
+	require.NoError(t, Record(client.SentItem{Channel: "C123", FileID: "F001", AsUser: true}))
+
+	j, err := loadJournal(journalPath())
+	require.NoError(t, err)
+	require.Len(t, j.Entries, 2)
+	assert.Equal(t, "message", j.Entries[0].Kind)
+	assert.Equal(t, hashText("hi"), j.Entries[0].TextHash)
+	assert.NotContains(t, j.Entries[0].TextHash, "hi")
+	assert.Equal(t, "file", j.Entries[1].Kind)
+	assert.Equal(t, "F001", j.Entries[1].FileID)
+	assert.True(t, j.Entries[1].AsUser)
+}
+
+func TestRecord_ParallelSends(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	require.NoError(t, setEnabled(journalPath(), true))
+
+	const senders = 8
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, Record(client.SentItem{Channel: "C123", TS: fmt.Sprintf("%d.0", i)}))
+		}(i)
+	}
+	wg.Wait()
+
+	j, err := loadJournal(journalPath())
+	require.NoError(t, err)
+	assert.Len(t, j.Entries, senders, "no send overwrites another's entry")
+	assert.NoFileExists(t, journalPath()+".lock")
+}
+
+func TestJournal_AddCapsEntries(t *testing.T) {
+	j := &journal{}
+	for i := 0; i < maxEntries+5; i++ {
+		j.add(entry{TS: strings.Repeat("x", i%3)})
+	}
+	assert.Len(t, j.Entries, maxEntries)
+}
+
+func TestRunList_JSON(t *testing.T) {
+	path := writeJournal(t, messageEntry("C123", "1.0"), messageEntry("C456", "2.0"))
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	require.NoError(t, runList(&listOptions{limit: 20, path: path}))
+
+	var listed []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &listed))
+	require.Len(t, listed, 2)
+	assert.Equal(t, float64(1), listed[0]["n"])
+	assert.Equal(t, "2.0", listed[0]["ts"])
+	assert.Equal(t, "C123", listed[1]["channel"])
+}
+
+func TestRunUndo_DeletesMostRecent(t *testing.T) {
+	path := writeJournal(t,
+		messageEntry("C123", "1.0"),
+		messageEntry("C123", "2.0"),
+		entry{Kind: "file", Channel: "C123", FileID: "F001", SentAt: time.Now()},
+	)
+
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/files.delete":
+			calls = append(calls, "file "+body["file"].(string))
+		case "/chat.delete":
+			calls = append(calls, "message "+body["ts"].(string))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &undoOptions{path: path, stdin: strings.NewReader("y\n")}
+
+	require.NoError(t, runUndo("2", opts, c))
+	assert.Equal(t, []string{"file F001", "message 2.0"}, calls)
+	assert.Contains(t, buf.String(), "About to delete 2 item(s)")
+
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	require.Len(t, j.Entries, 1)
+	assert.Equal(t, "1.0", j.Entries[0].TS)
+}
+
+func TestRunUndo_Cancelled(t *testing.T) {
+	path := writeJournal(t, messageEntry("C123", "1.0"))
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	opts := &undoOptions{path: path, stdin: strings.NewReader("n\n")}
+	require.NoError(t, runUndo("1", opts, nil))
+	assert.Contains(t, buf.String(), "Cancelled.")
+
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	assert.Len(t, j.Entries, 1)
+}
+
+func TestRunUndo_NoAnswerDoesNotDelete(t *testing.T) {
+	path := writeJournal(t, messageEntry("C123", "1.0"))
+
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	opts := &undoOptions{path: path, stdin: strings.NewReader("")}
+	err := runUndo("1", opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	assert.Len(t, j.Entries, 1, "closed stdin is not a yes")
+}
+
+func TestRunUndo_AlreadyDeleted(t *testing.T) {
+	path := writeJournal(t, messageEntry("C123", "1.0"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "message_not_found"})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runUndo("1", &undoOptions{path: path, force: true}, c))
+
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	assert.Empty(t, j.Entries)
+}
+
+func TestRunUndo_Empty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sent.json")
+
+	err := runUndo("1", &undoOptions{path: path, force: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sent journal is disabled")
+}
+
+func TestRunEdit_UpdatesMessage(t *testing.T) {
+	path := writeJournal(t, messageEntry("C123", "1.0"), messageEntry("C456", "2.0"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.update", r.URL.Path)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "C123", body["channel"])
+		assert.Equal(t, "1.0", body["ts"])
+		assert.Equal(t, "corrected", body["text"])
+		assert.Nil(t, body["blocks"])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runEdit("2", "corrected", &editOptions{simple: true, path: path}, c))
+	assert.Contains(t, buf.String(), "Message updated (ts: 1.0)")
+
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	assert.Equal(t, hashText("corrected"), j.Entries[0].TextHash)
+}
+
+func TestRunEdit_Errors(t *testing.T) {
+	path := writeJournal(t, entry{Kind: "file", Channel: "C123", FileID: "F001", SentAt: time.Now()})
+
+	err := runEdit("1", "text", &editOptions{path: path}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a file")
+
+	err = runEdit("5", "text", &editOptions{path: path}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no sent entry 5")
+
+	err = runEdit("0", "text", &editOptions{path: path}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a positive integer")
+}
@@ -0,0 +1,162 @@
+package sent
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type undoOptions struct {
+	force bool
+	path  string    // For testing
+	stdin io.Reader // For testing
+}
+
+// NewUndoCmd creates the top-level undo command
+func NewUndoCmd() *cobra.Command {
+	opts := &undoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "undo [n]",
+		Short: "Delete the last n messages or files sent by slck",
+		Long: `Delete the most recent messages and files recorded in the sent journal.
+
+Deletes the last one by default. Each item is deleted with the same token it
+was sent with. You are asked to confirm unless --force is given; if nothing
+answers the prompt, as in a script or CI job, nothing is deleted. Requires the
+sent journal (see "slck sent enable").
+
+Examples:
+  slck undo
+  slck undo 3 --force`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := "1"
+			if len(args) == 1 {
+				n = args[0]
+			}
+			return runUndo(n, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// undoResult is the JSON record of an undo
+type undoResult struct {
+	Deleted []entry `json:"deleted"`
+}
+
+func runUndo(arg string, opts *undoOptions, c *client.Client) error {
+	n, err := parseCount(arg)
+	if err != nil {
+		return err
+	}
+
+	path := opts.path
+	if path == "" {
+		path = journalPath()
+	}
+	j, err := loadJournal(path)
+	if err != nil {
+		return err
+	}
+
+	entries := j.recent(n)
+	if len(entries) == 0 {
+		if !j.Enabled {
+			return fmt.Errorf("nothing to undo: the sent journal is disabled (turn it on with: slck sent enable)")
+		}
+		return fmt.Errorf("nothing to undo: no sent messages recorded")
+	}
+
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to delete %d item(s):\n", len(entries))
+		for _, e := range entries {
+			output.Printf("  %s (sent %s)\n", describe(e), e.SentAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		output.Printf("Are you sure? [y/N]: ")
+
+		// No answer (such as stdin closed in CI) never goes ahead with the deletes
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println()
+			return fmt.Errorf("no answer to the confirmation prompt; use --force to undo without confirming")
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	clients := map[bool]*client.Client{}
+	if c != nil {
+		clients[false], clients[true] = c, c
+	}
+
+	result := undoResult{Deleted: []entry{}}
+	var undoErr error
+	for _, e := range entries {
+		ec, err := clientFor(e, clients)
+		if err != nil {
+			undoErr = err
+			break
+		}
+
+		if e.Kind == "file" {
+			err = ec.DeleteFile(e.FileID)
+		} else {
+			err = ec.DeleteMessage(e.Channel, e.TS)
+		}
+		if err != nil && !isAlreadyGone(err) {
+			undoErr = client.WrapError(fmt.Sprintf("delete %s", describe(e)), err)
+			break
+		}
+
+		result.Deleted = append(result.Deleted, e)
+		if !output.IsJSON() {
+			output.Printf("Deleted %s\n", describe(e))
+		}
+	}
+
+	err = j.update(func() {
+		for _, e := range result.Deleted {
+			j.remove(e)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("writing sent journal: %w", err)
+	}
+	if undoErr != nil {
+		return undoErr
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(result)
+	}
+	return nil
+}
+
+// isAlreadyGone reports whether a delete failed because the item no longer
+// exists, in which case there is nothing left to undo
+func isAlreadyGone(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "message_not_found") || strings.Contains(msg, "file_not_found") ||
+		strings.Contains(msg, "file_deleted")
+}
@@ -0,0 +1,309 @@
+package synccmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+)
+
+const (
+	historyPageSize  = 200
+	maxThreadReplies = 100000
+	maxUsers         = 100000
+)
+
+type syncOptions struct {
+	dir        string
+	since      string
+	threadDays int
+	full       bool
+}
+
+// syncResult is the JSON output for one synced channel
+type syncResult struct {
+	Channel     string `json:"channel"`
+	Name        string `json:"name"`
+	NewMessages int    `json:"new_messages"`
+	NewReplies  int    `json:"new_replies"`
+	Messages    int    `json:"messages"`
+	Replies     int    `json:"replies"`
+	Checkpoint  string `json:"checkpoint,omitempty"`
+}
+
+// NewCmd creates the sync command
+func NewCmd() *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync <channel>...",
+		Short: "Mirror channel history locally for offline reading and search",
+		Long: `Copy channel history, including thread replies, into a local mirror that
+the slck local commands read without calling Slack.
+
+The first sync fetches the whole history (or back to --since). After that,
+each sync fetches messages newer than the last one synced. Messages from the
+last --thread-days days are fetched again too, each with its whole thread, so
+edits, reactions and new replies are picked up. Threads started before then
+aren't checked again.
+
+The mirror lives in the mirror directory under the config directory.
+
+--since accepts a Slack timestamp or:
+` + timeparse.Syntax + `
+
+Examples:
+  slck sync general incidents
+  slck sync incidents --since 2024-01-01
+  slck local search "timeout|deadline" --channel incidents`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runSync(ctx, args, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", "", "Mirror directory (default: mirror in the config directory)")
+	cmd.Flags().StringVar(&opts.since, "since", "", "On a channel's first sync, only fetch messages after this time")
+	cmd.Flags().IntVar(&opts.threadDays, "thread-days", 7, "Fetch messages from this many recent days again to pick up edits and new replies")
+	cmd.Flags().BoolVar(&opts.full, "full", false, "Fetch everything again instead of starting from the last sync")
+
+	return cmd
+}
+
+func runSync(ctx context.Context, channels []string, opts *syncOptions, c *client.Client) error {
+	if opts.threadDays < 0 {
+		return fmt.Errorf("--thread-days can't be negative")
+	}
+	since, err := timeparse.Bound(opts.since, "--since")
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	dir := opts.dir
+	if dir == "" {
+		dir = mirror.DefaultDir()
+	}
+	s := &syncer{ctx: ctx, c: c, store: mirror.Open(dir), opts: opts, since: since}
+
+	if err := s.syncUsers(); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return fmt.Errorf("sync interrupted")
+		}
+		fmt.Fprintf(os.Stderr, "Warning: couldn't sync user names: %v\n", err)
+	}
+
+	var results []syncResult
+	for _, channel := range channels {
+		result, err := s.syncChannel(channel)
+		if errors.Is(err, context.Canceled) {
+			return fmt.Errorf("sync interrupted; channels synced so far are saved")
+		}
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		if !output.IsJSON() {
+			output.Printf("#%s: %d new %s, %d new %s (%d %s and %d %s mirrored)\n",
+				result.Name, result.NewMessages, plural(result.NewMessages, "message", "messages"),
+				result.NewReplies, plural(result.NewReplies, "reply", "replies"),
+				result.Messages, plural(result.Messages, "message", "messages"),
+				result.Replies, plural(result.Replies, "reply", "replies"))
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(results)
+	}
+	return nil
+}
+
+// syncer runs one sync
+type syncer struct {
+	ctx   context.Context
+	c     *client.Client
+	store *mirror.Store
+	opts  *syncOptions
+	since string
+}
+
+// syncUsers records the workspace's user names so the mirror can show
+// authors offline
+func (s *syncer) syncUsers() error {
+	var users []client.User
+	if err := s.call(func() (err error) {
+		users, err = s.c.ListUsers(maxUsers)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	names, err := s.store.Users()
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		name := u.Profile.DisplayName
+		if name == "" {
+			name = u.RealName
+		}
+		if name == "" {
+			name = u.Name
+		}
+		names[u.ID] = name
+	}
+	return s.store.SaveUsers(names)
+}
+
+// syncChannel fetches what's new in a channel and merges it into the mirror
+func (s *syncer) syncChannel(channel string) (syncResult, error) {
+	id, err := s.c.ResolveChannel(channel)
+	if err != nil {
+		return syncResult{}, err
+	}
+	ch, err := s.store.Channel(id)
+	if err != nil {
+		return syncResult{}, err
+	}
+	// With --full the mirror keeps what it has, so new counts are still
+	// measured against it
+	var beforeMessages, beforeReplies int
+	if ch != nil {
+		beforeMessages, beforeReplies = ch.Messages, ch.Replies
+	}
+	if ch == nil || s.opts.full {
+		ch = &mirror.Channel{ID: id}
+	}
+
+	var info *client.Channel
+	if err := s.call(func() (err error) {
+		info, err = s.c.GetChannelInfo(id)
+		return err
+	}); err != nil {
+		return syncResult{}, client.WrapError("get channel info", err)
+	}
+	ch.Name = info.Name
+	if ch.Name == "" {
+		ch.Name = id
+	}
+
+	history, err := s.history(id, s.oldest(ch))
+	if err != nil {
+		return syncResult{}, client.WrapError("get history", err)
+	}
+
+	messages := history
+	for _, m := range history {
+		if mirror.IsReply(m) {
+			continue
+		}
+		if client.CompareTimestamps(m.TS, ch.Checkpoint) > 0 {
+			ch.Checkpoint = m.TS
+		}
+		if m.ReplyCount == 0 {
+			continue
+		}
+		// The whole thread is fetched, not just new replies, so edits and
+		// reactions on replies synced before are picked up too
+		replies, err := s.replies(id, m.TS)
+		if err != nil {
+			return syncResult{}, client.WrapError("get thread", err)
+		}
+		for _, r := range replies {
+			if r.TS != m.TS { // Slack always includes the parent
+				messages = append(messages, r)
+			}
+		}
+	}
+
+	ch.SyncedAt = time.Now()
+	if err := s.store.Save(ch, messages); err != nil {
+		return syncResult{}, fmt.Errorf("saving mirror of #%s: %w", ch.Name, err)
+	}
+
+	return syncResult{
+		Channel:     ch.ID,
+		Name:        ch.Name,
+		NewMessages: ch.Messages - beforeMessages,
+		NewReplies:  ch.Replies - beforeReplies,
+		Messages:    ch.Messages,
+		Replies:     ch.Replies,
+		Checkpoint:  ch.Checkpoint,
+	}, nil
+}
+
+// oldest returns where a channel's sync starts: after the checkpoint, but
+// far enough back to look at recent messages again
+func (s *syncer) oldest(ch *mirror.Channel) string {
+	if ch.Checkpoint == "" {
+		return s.since
+	}
+	oldest := ch.Checkpoint
+	if s.opts.threadDays > 0 {
+		window := fmt.Sprintf("%d.000000", time.Now().AddDate(0, 0, -s.opts.threadDays).Unix())
+		if client.CompareTimestamps(window, oldest) < 0 {
+			oldest = window
+		}
+	}
+	return oldest
+}
+
+// history fetches every message after oldest, paging back from the newest
+func (s *syncer) history(id, oldest string) ([]client.Message, error) {
+	var all []client.Message
+	latest := ""
+	for {
+		var page []client.Message
+		if err := s.call(func() (err error) {
+			page, err = s.c.GetChannelHistory(id, historyPageSize, oldest, latest)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return all, nil
+		}
+		all = append(all, page...)
+		latest = page[len(page)-1].TS
+	}
+}
+
+// replies fetches all of a thread's replies
+func (s *syncer) replies(id, threadTS string) ([]client.Message, error) {
+	var replies []client.Message
+	err := s.call(func() (err error) {
+		replies, err = s.c.GetThreadReplies(id, threadTS, maxThreadReplies)
+		return err
+	})
+	return replies, err
+}
+
+// call runs fn, waiting out and retrying rate limited attempts
+func (s *syncer) call(fn func() error) error {
+	return client.RetryRateLimited(s.ctx, client.MaxRateLimitRetries, fn)
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,265 @@
+package synccmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mirror"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// fakeSlack serves #incidents from its messages and thread replies
+type fakeSlack struct {
+	messages     []map[string]interface{}
+	replies      map[string][]map[string]interface{}
+	rateLimited  int32 // Rate limit this many history calls first
+	oldests      []string
+	replyOldests []string
+}
+
+func newFakeSlack() *fakeSlack {
+	return &fakeSlack{
+		messages: []map[string]interface{}{
+			{"ts": "1700000000.000100", "user": "U001", "text": "Site is down", "thread_ts": "1700000000.000100",
+				"reply_count": 1, "latest_reply": "1700000300.000100"},
+			{"ts": "1700000600.000100", "user": "U002", "text": "All clear"},
+		},
+		replies: map[string][]map[string]interface{}{
+			"1700000000.000100": {
+				{"ts": "1700000300.000100", "user": "U002", "text": "Looking", "thread_ts": "1700000000.000100"},
+			},
+		},
+	}
+}
+
+func (f *fakeSlack) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"members": []map[string]interface{}{
+					{"id": "U001", "name": "alice", "profile": map[string]interface{}{"display_name": "Alice"}},
+					{"id": "U002", "name": "bob"},
+				},
+			})
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "incidents"},
+			})
+		case "/conversations.history":
+			if atomic.AddInt32(&f.rateLimited, -1) >= 0 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			oldest, latest := q.Get("oldest"), q.Get("latest")
+			if latest == "" {
+				f.oldests = append(f.oldests, oldest)
+			}
+			limit, _ := strconv.Atoi(q.Get("limit"))
+			var page []map[string]interface{}
+			for _, m := range f.messages {
+				ts := m["ts"].(string)
+				if client.CompareTimestamps(ts, oldest) > 0 && (latest == "" || client.CompareTimestamps(ts, latest) < 0) {
+					page = append(page, m)
+				}
+			}
+			sort.Slice(page, func(i, j int) bool {
+				return client.CompareTimestamps(page[i]["ts"].(string), page[j]["ts"].(string)) > 0
+			})
+			if len(page) > limit {
+				page = page[:limit]
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": page})
+		case "/conversations.replies":
+			ts, oldest := q.Get("ts"), q.Get("oldest")
+			f.replyOldests = append(f.replyOldests, oldest)
+			var parent map[string]interface{}
+			for _, m := range f.messages {
+				if m["ts"] == ts {
+					parent = m
+				}
+			}
+			messages := []map[string]interface{}{parent}
+			for _, r := range f.replies[ts] {
+				if client.CompareTimestamps(r["ts"].(string), oldest) > 0 {
+					messages = append(messages, r)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": messages})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+}
+
+func TestRunSync_FirstSync(t *testing.T) {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack()
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	dir := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, &syncOptions{dir: dir, threadDays: 7}, c))
+
+	assert.Equal(t, "#incidents: 2 new messages, 1 new reply (2 messages and 1 reply mirrored)\n", buf.String())
+	assert.Equal(t, []string{""}, fake.oldests, "the first sync fetches the whole history")
+	assert.Equal(t, []string{""}, fake.replyOldests)
+
+	store := mirror.Open(dir)
+	ch, err := store.Channel("C123")
+	require.NoError(t, err)
+	require.NotNil(t, ch)
+	assert.Equal(t, "incidents", ch.Name)
+	assert.Equal(t, "1700000600.000100", ch.Checkpoint)
+
+	messages, err := store.Messages("C123")
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	assert.Equal(t, "Looking", messages[1].Text)
+
+	users, err := store.Users()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"U001": "Alice", "U002": "bob"}, users)
+}
+
+func TestRunSync_Incremental(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack()
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	dir := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &syncOptions{dir: dir}
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, opts, c))
+
+	// A new message and a new reply to the existing thread
+	fake.messages[0]["reply_count"] = 2
+	fake.messages[0]["latest_reply"] = "1700000900.000100"
+	fake.replies["1700000000.000100"] = append(fake.replies["1700000000.000100"],
+		map[string]interface{}{"ts": "1700000900.000100", "user": "U001", "text": "Fixed", "thread_ts": "1700000000.000100"})
+	fake.messages = append(fake.messages, map[string]interface{}{"ts": "1700001200.000100", "user": "U001", "text": "Postmortem tomorrow"})
+	fake.oldests, fake.replyOldests = nil, nil
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = output.FormatText }()
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, opts, c))
+
+	// With no re-scan window the next sync starts at the checkpoint, which
+	// doesn't include the thread parent, so the new reply isn't seen yet
+	assert.Equal(t, []string{"1700000600.000100"}, fake.oldests)
+	assert.Empty(t, fake.replyOldests)
+
+	var results []syncResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, syncResult{
+		Channel: "C123", Name: "incidents", NewMessages: 1, NewReplies: 0,
+		Messages: 3, Replies: 1, Checkpoint: "1700001200.000100",
+	}, results[0])
+
+	// A re-scan window reaching back over the thread picks up its new reply,
+	// and an edit to the reply synced before
+	fake.replies["1700000000.000100"][0]["text"] = "Looking (edited)"
+	buf.Reset()
+	fake.oldests = nil
+	opts.threadDays = 10000
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, opts, c))
+	require.Len(t, fake.oldests, 1)
+	assert.Equal(t, -1, client.CompareTimestamps(fake.oldests[0], "1700000000.000100"))
+	assert.Equal(t, []string{""}, fake.replyOldests, "the whole thread is fetched")
+
+	results = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	assert.Equal(t, 0, results[0].NewMessages)
+	assert.Equal(t, 1, results[0].NewReplies)
+	assert.Equal(t, 2, results[0].Replies)
+
+	msgs, err := mirror.Open(dir).Messages("C123")
+	require.NoError(t, err)
+	var texts []string
+	for _, m := range msgs {
+		texts = append(texts, m.Text)
+	}
+	assert.Contains(t, texts, "Looking (edited)")
+}
+
+func TestRunSync_FullCountsOnlyNewMessages(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack()
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	dir := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, &syncOptions{dir: dir}, c))
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = output.FormatText }()
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, &syncOptions{dir: dir, full: true}, c))
+
+	var results []syncResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].NewMessages, "messages already mirrored aren't new")
+	assert.Equal(t, 0, results[0].NewReplies)
+	assert.Equal(t, 2, results[0].Messages)
+	assert.Equal(t, 1, results[0].Replies)
+}
+
+func TestRunSync_RetriesRateLimits(t *testing.T) {
+	output.Writer = io.Discard
+	defer func() { output.Writer = os.Stdout }()
+
+	fake := newFakeSlack()
+	fake.rateLimited = 2
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	dir := t.TempDir()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runSync(context.Background(), []string{"C123"}, &syncOptions{dir: dir}, c))
+
+	ch, err := mirror.Open(dir).Channel("C123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, ch.Messages)
+}
+
+func TestRunSync_Validation(t *testing.T) {
+	err := runSync(context.Background(), []string{"C123"}, &syncOptions{threadDays: -1}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--thread-days")
+
+	err = runSync(context.Background(), []string{"C123"}, &syncOptions{since: "someday"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--since")
+}
@@ -0,0 +1,155 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/timeparse"
+	"github.com/open-cli-collective/slack-chat-api/internal/transcript"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+// maxThreadReplies caps the replies fetched for each thread
+const maxThreadReplies = 1000
+
+type transcriptOptions struct {
+	format string
+	since  string
+	until  string
+	limit  int
+}
+
+// NewCmd creates the transcript command
+func NewCmd() *cobra.Command {
+	opts := &transcriptOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "transcript <channel|permalink>",
+		Short: "Write a conversation as a Markdown or HTML document",
+		Long: `Write a channel's messages, or a single thread, as a readable document for
+postmortems and docs. Names are resolved, times are shown in local time,
+thread replies are nested under their parent and reactions, code blocks and
+file links are kept.
+
+Given a channel, its recent top-level messages are written with their
+threads. Given a permalink, just that message's thread is written.
+
+--format html writes a single self-contained page with inline styles.
+
+--since and --until accept Slack timestamps or:
+` + timeparse.Syntax + `
+
+Examples:
+  slck transcript incident-42 --since "2 days ago" > postmortem.md
+  slck transcript https://example.slack.com/archives/C123/p1700000000000100 --format html > thread.html`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTranscript(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "md", "Document format: md or html")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only messages after this time (channels only)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only messages before this time (channels only)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum top-level messages (channels only)")
+
+	return cmd
+}
+
+func runTranscript(target string, opts *transcriptOptions, c *client.Client) error {
+	format := strings.ToLower(opts.format)
+	switch format {
+	case "md", "markdown", "html":
+	default:
+		return fmt.Errorf("invalid format %q: must be md or html", opts.format)
+	}
+
+	ref, isPermalink := validate.ParsePermalink(target)
+	if isPermalink && (opts.since != "" || opts.until != "") {
+		return fmt.Errorf("--since and --until can't be used with a permalink")
+	}
+	oldest, err := timeparse.Bound(opts.since, "--since")
+	if err != nil {
+		return err
+	}
+	latest, err := timeparse.Bound(opts.until, "--until")
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID := ref.Channel
+	if !isPermalink {
+		channelID, err = c.ResolveChannel(target)
+		if err != nil {
+			return err
+		}
+	}
+	channelName := client.NewChannelResolver(c).Resolve(channelID)
+
+	t := &transcript.Transcript{Users: client.NewUserResolver(c)}
+	t.Renderer = mrkdwn.NewRenderer(c, t.Users)
+
+	if isPermalink {
+		threadTS := ref.TS
+		if ref.ThreadTS != "" {
+			threadTS = ref.ThreadTS
+		}
+		messages, err := c.GetThreadReplies(channelID, threadTS, maxThreadReplies)
+		if err != nil {
+			return client.WrapError("get thread", err)
+		}
+		if len(messages) == 0 {
+			return fmt.Errorf("message %s not found in #%s", threadTS, channelName)
+		}
+		t.Title = "Thread in #" + channelName
+		t.Threads = []transcript.Thread{transcript.NewThread(messages)}
+	} else {
+		t.Title = "#" + channelName
+		if t.Threads, err = channelThreads(c, channelID, opts.limit, oldest, latest); err != nil {
+			return err
+		}
+	}
+
+	if format == "html" {
+		return t.WriteHTML(output.Writer)
+	}
+	return t.WriteMarkdown(output.Writer)
+}
+
+// channelThreads returns a channel's top-level messages, oldest first, with
+// the replies to each
+func channelThreads(c *client.Client, channelID string, limit int, oldest, latest string) ([]transcript.Thread, error) {
+	history, err := c.GetChannelHistory(channelID, limit, oldest, latest)
+	if err != nil {
+		return nil, client.WrapError("get history", err)
+	}
+
+	// History comes newest first
+	threads := make([]transcript.Thread, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		m := history[i]
+		th := transcript.Thread{Message: m}
+		if m.ReplyCount > 0 {
+			replies, err := c.GetThreadReplies(channelID, m.TS, maxThreadReplies)
+			if err != nil {
+				return nil, client.WrapError("get thread", err)
+			}
+			th = transcript.NewThread(replies)
+			th.Message = m
+		}
+		threads = append(threads, th)
+	}
+	return threads, nil
+}
@@ -0,0 +1,121 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// newServer serves #incidents with two top-level messages, the older of
+// which has a reply
+func newServer(t *testing.T) (*httptest.Server, *[]string) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "incidents"},
+			})
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000600.000100", "user": "U002", "text": "All clear"},
+					{"ts": "1700000000.000100", "user": "U001", "text": "Site is down", "reply_count": 1, "thread_ts": "1700000000.000100",
+						"reactions": []map[string]interface{}{{"name": "eyes", "count": 1, "users": []string{"U002"}}}},
+				},
+			})
+		case "/conversations.replies":
+			assert.Equal(t, "1700000000.000100", r.URL.Query().Get("ts"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000000.000100", "user": "U001", "text": "Site is down"},
+					{"ts": "1700000300.000100", "user": "U002", "text": "Looking", "thread_ts": "1700000000.000100"},
+				},
+			})
+		case "/users.info":
+			name := map[string]string{"U001": "alice", "U002": "bob"}[r.URL.Query().Get("user")]
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": r.URL.Query().Get("user"), "name": name},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	return server, &calls
+}
+
+func TestRunTranscript_ChannelMarkdown(t *testing.T) {
+	server, _ := newServer(t)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runTranscript("C123", &transcriptOptions{format: "md", limit: 100}, c)
+	require.NoError(t, err)
+
+	got := buf.String()
+	assert.True(t, strings.HasPrefix(got, "# #incidents\n"))
+	assert.Contains(t, got, "2 messages, 1 reply")
+
+	// Oldest first, with the reply nested under its parent
+	down := strings.Index(got, "Site is down")
+	looking := strings.Index(got, "> Looking")
+	allClear := strings.Index(got, "All clear")
+	assert.True(t, down >= 0 && down < looking && looking < allClear, got)
+	assert.Contains(t, got, "👀 1", "reactions come from the history message")
+}
+
+func TestRunTranscript_PermalinkHTML(t *testing.T) {
+	server, calls := newServer(t)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	link := "https://example.slack.com/archives/C123/p1700000300000100?thread_ts=1700000000.000100&cid=C123"
+	err := runTranscript(link, &transcriptOptions{format: "html", limit: 100}, c)
+	require.NoError(t, err)
+
+	got := buf.String()
+	assert.True(t, strings.HasPrefix(got, "<!DOCTYPE html>"))
+	assert.Contains(t, got, "<title>Thread in #incidents</title>")
+	assert.Contains(t, got, `<div class="replies">`)
+	assert.Contains(t, got, "Looking")
+	for _, call := range *calls {
+		assert.NotContains(t, call, "conversations.history", "a permalink only fetches its thread")
+	}
+}
+
+func TestRunTranscript_Validation(t *testing.T) {
+	err := runTranscript("C123", &transcriptOptions{format: "pdf"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be md or html")
+
+	err = runTranscript("https://example.slack.com/archives/C123/p1700000000000100", &transcriptOptions{format: "md", since: "1 day ago"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can't be used with a permalink")
+
+	err = runTranscript("C123", &transcriptOptions{format: "md", until: "someday"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--until")
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -47,11 +48,47 @@ func runGet(userID string, opts *getOptions, c *client.Client) error {
 	output.KeyValue("Real Name", user.RealName)
 	output.KeyValue("Display Name", user.Profile.DisplayName)
 	output.KeyValue("Email", user.Profile.Email)
+	if user.Profile.Title != "" {
+		output.KeyValue("Title", user.Profile.Title)
+	}
+	if user.Profile.Phone != "" {
+		output.KeyValue("Phone", user.Profile.Phone)
+	}
+	if user.TZ != "" {
+		output.KeyValue("Timezone", fmt.Sprintf("%s (%s)", user.TZ, user.TZLabel))
+	}
+	output.KeyValue("Role", userRole(*user))
 	output.KeyValue("Admin", user.IsAdmin)
 	output.KeyValue("Bot", user.IsBot)
+	output.KeyValue("Deactivated", user.Deleted)
 	if user.Profile.StatusText != "" {
 		output.KeyValue("Status", fmt.Sprintf("%s %s", user.Profile.StatusEmoji, user.Profile.StatusText))
 	}
+	if user.Profile.Image512 != "" {
+		output.KeyValue("Image", user.Profile.Image512)
+	}
+	if user.Updated != 0 {
+		output.KeyValue("Updated", time.Unix(user.Updated, 0).Format("2006-01-02 15:04"))
+	}
 
 	return nil
 }
+
+// userRole describes a user's account type, from primary owner down to guest
+func userRole(u client.User) string {
+	switch {
+	case u.IsPrimaryOwner:
+		return "primary owner"
+	case u.IsOwner:
+		return "owner"
+	case u.IsAdmin:
+		return "admin"
+	case u.IsUltraRestricted:
+		return "single-channel guest"
+	case u.IsRestricted:
+		return "multi-channel guest"
+	case u.IsBot:
+		return "bot"
+	}
+	return "member"
+}
@@ -1,6 +1,9 @@
 package users
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
@@ -8,7 +11,15 @@ import (
 )
 
 type listOptions struct {
-	limit int
+	limit       int
+	includeBots bool
+	bots        bool
+	admins      bool
+	owners      bool
+	guests      bool
+	deleted     bool
+	active      bool
+	tz          string
 }
 
 func newListCmd() *cobra.Command {
@@ -17,30 +28,96 @@ func newListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all users",
+		Long: `List all users in the workspace.
+
+Bot users are left out of text and table output unless --include-bots or
+--bots is given. Filters can be combined; a user must match all of them.
+With filters, every user in the workspace is searched and --limit caps the
+number of matches.
+
+Examples:
+  slck users list --guests
+  slck users list --admins --active
+  slck users list --tz Europe/ -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(opts, nil)
 		},
 	}
 
 	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum users to return")
+	cmd.Flags().BoolVar(&opts.includeBots, "include-bots", false, "Include bot users in text and table output")
+	cmd.Flags().BoolVar(&opts.bots, "bots", false, "Only bot users")
+	cmd.Flags().BoolVar(&opts.admins, "admins", false, "Only admins and owners")
+	cmd.Flags().BoolVar(&opts.owners, "owners", false, "Only workspace owners")
+	cmd.Flags().BoolVar(&opts.guests, "guests", false, "Only single- and multi-channel guests")
+	cmd.Flags().BoolVar(&opts.deleted, "deleted", false, "Only deactivated users")
+	cmd.Flags().BoolVar(&opts.active, "active", false, "Only users who aren't deactivated")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Only users whose timezone contains this text (e.g. America/, Berlin)")
 
 	return cmd
 }
 
+// filtering reports whether any of the list filters are set
+func (opts *listOptions) filtering() bool {
+	return opts.bots || opts.admins || opts.owners || opts.guests || opts.deleted || opts.active || opts.tz != ""
+}
+
+// matches reports whether u passes all of the list filters
+func (opts *listOptions) matches(u client.User) bool {
+	switch {
+	case opts.bots && !u.IsBot:
+		return false
+	case opts.admins && !(u.IsAdmin || u.IsOwner):
+		return false
+	case opts.owners && !u.IsOwner:
+		return false
+	case opts.guests && !u.IsGuest():
+		return false
+	case opts.deleted && !u.Deleted:
+		return false
+	case opts.active && u.Deleted:
+		return false
+	case opts.tz != "" && !strings.Contains(strings.ToLower(u.TZ+" "+u.TZLabel), strings.ToLower(opts.tz)):
+		return false
+	}
+	return true
+}
+
 func runList(opts *listOptions, c *client.Client) error {
+	if opts.deleted && opts.active {
+		return fmt.Errorf("only one of --deleted or --active can be specified")
+	}
+
+	var err error
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
 		}
 	}
 
-	users, err := c.ListUsers(opts.limit)
+	// Filters look at every user, so --limit caps the matches rather than
+	// the users searched
+	var all []client.User
+	if opts.filtering() {
+		all, err = c.ListAllUsers()
+	} else {
+		all, err = c.ListUsers(opts.limit)
+	}
 	if err != nil {
 		return err
 	}
 
+	users := make([]client.User, 0, len(all))
+	for _, u := range all {
+		if opts.matches(u) {
+			users = append(users, u)
+		}
+	}
+	if len(users) > opts.limit {
+		users = users[:opts.limit]
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(users)
 	}
@@ -50,13 +127,17 @@ func runList(opts *listOptions, c *client.Client) error {
 		return nil
 	}
 
-	headers := []string{"ID", "USERNAME", "REAL NAME", "EMAIL"}
+	headers := []string{"ID", "USERNAME", "REAL NAME", "EMAIL", "TIMEZONE", "ROLE"}
 	rows := make([][]string, 0, len(users))
 	for _, u := range users {
-		if u.IsBot {
+		if u.IsBot && !opts.includeBots && !opts.bots {
 			continue
 		}
-		rows = append(rows, []string{u.ID, u.Name, u.RealName, u.Profile.Email})
+		role := userRole(u)
+		if u.Deleted {
+			role += " (deactivated)"
+		}
+		rows = append(rows, []string{u.ID, u.Name, u.RealName, u.Profile.Email, u.TZ, role})
 	}
 	output.Table(headers, rows)
 
@@ -1,15 +1,19 @@
 package users
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
 func TestRunList_Success(t *testing.T) {
@@ -190,3 +194,115 @@ func TestRunGet_NoStatus(t *testing.T) {
 	err := runGet("U001", opts, c)
 	require.NoError(t, err)
 }
+
+func TestRunList_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"members": []map[string]interface{}{
+				{"id": "U001", "name": "alice", "is_admin": true, "tz": "Europe/Berlin"},
+				{"id": "U002", "name": "bob", "is_restricted": true, "tz": "America/New_York"},
+				{"id": "U003", "name": "carol", "is_ultra_restricted": true, "deleted": true, "tz": "Europe/London"},
+				{"id": "U004", "name": "dave", "is_owner": true, "tz": "America/Chicago"},
+				{"id": "B001", "name": "ci", "is_bot": true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want []string
+	}{
+		{"admins include owners", listOptions{admins: true}, []string{"U001", "U004"}},
+		{"owners", listOptions{owners: true}, []string{"U004"}},
+		{"guests", listOptions{guests: true}, []string{"U002", "U003"}},
+		{"active guests", listOptions{guests: true, active: true}, []string{"U002"}},
+		{"deleted", listOptions{deleted: true}, []string{"U003"}},
+		{"timezone", listOptions{tz: "europe/"}, []string{"U001", "U003"}},
+		{"bots", listOptions{bots: true}, []string{"B001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			output.Writer = &buf
+			output.OutputFormat = output.FormatJSON
+			defer func() {
+				output.Writer = os.Stdout
+				output.OutputFormat = output.FormatText
+			}()
+
+			c := client.NewWithConfig(server.URL, "test-token", nil)
+			opts := tt.opts
+			opts.limit = 100
+			require.NoError(t, runList(&opts, c))
+
+			var users []client.User
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &users))
+			ids := make([]string, 0, len(users))
+			for _, u := range users {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRunList_FiltersSearchEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		if r.URL.Query().Get("cursor") == "" {
+			// A first page of members fills the default --limit
+			var members []map[string]interface{}
+			for i := 0; i < 100; i++ {
+				members = append(members, map[string]interface{}{"id": fmt.Sprintf("U%03d", i), "name": fmt.Sprintf("member%d", i)})
+			}
+			resp["members"] = members
+			resp["response_metadata"] = map[string]string{"next_cursor": "page2"}
+		} else {
+			resp["members"] = []map[string]interface{}{
+				{"id": "UG1", "name": "guest1", "is_restricted": true},
+				{"id": "UG2", "name": "guest2", "is_restricted": true},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	require.NoError(t, runList(&listOptions{limit: 100, guests: true}, c))
+	var users []client.User
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &users))
+	require.Len(t, users, 2, "guests on the second page are found")
+
+	// --limit applies to the matches
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{limit: 1, guests: true}, c))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &users))
+	require.Len(t, users, 1)
+	assert.Equal(t, "UG1", users[0].ID)
+}
+
+func TestRunList_DeletedAndActiveConflict(t *testing.T) {
+	err := runList(&listOptions{limit: 100, deleted: true, active: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of --deleted or --active")
+}
+
+func TestUserRole(t *testing.T) {
+	assert.Equal(t, "primary owner", userRole(client.User{IsPrimaryOwner: true, IsOwner: true, IsAdmin: true}))
+	assert.Equal(t, "admin", userRole(client.User{IsAdmin: true}))
+	assert.Equal(t, "single-channel guest", userRole(client.User{IsRestricted: true, IsUltraRestricted: true}))
+	assert.Equal(t, "multi-channel guest", userRole(client.User{IsRestricted: true}))
+	assert.Equal(t, "member", userRole(client.User{}))
+}
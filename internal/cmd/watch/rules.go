@@ -0,0 +1,95 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule describes which messages trigger a command and what to do with the result
+type Rule struct {
+	Channel string   `yaml:"channel"`
+	Match   string   `yaml:"match"`
+	Users   []string `yaml:"users"`
+	Exec    string   `yaml:"exec"`
+	Reply   bool     `yaml:"reply"`
+	React   bool     `yaml:"react"`
+
+	pattern   *regexp.Regexp
+	channelID string
+}
+
+// rulesFile is the on-disk format of a --rules file
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// loadRules reads and validates rules from a YAML file
+func loadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file: %w", err)
+	}
+
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing rules file: %w", err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("rules file %s contains no rules", path)
+	}
+
+	for i := range file.Rules {
+		if err := file.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// compile validates the rule and prepares its regular expression
+func (r *Rule) compile() error {
+	if r.Channel == "" {
+		return fmt.Errorf("channel is required")
+	}
+	if r.Exec == "" {
+		return fmt.Errorf("exec is required")
+	}
+
+	pattern := r.Match
+	if pattern == "" {
+		pattern = ".*"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid match pattern %q: %w", r.Match, err)
+	}
+	r.pattern = re
+	return nil
+}
+
+// matches reports whether the message text and author satisfy the rule.
+// userName is the author's resolved display name, used for name-based user filters.
+func (r *Rule) matches(text, userID, userName string) ([]string, bool) {
+	if len(r.Users) > 0 && !r.matchesUser(userID, userName) {
+		return nil, false
+	}
+	groups := r.pattern.FindStringSubmatch(text)
+	if groups == nil {
+		return nil, false
+	}
+	return groups, true
+}
+
+func (r *Rule) matchesUser(userID, userName string) bool {
+	for _, u := range r.Users {
+		u = strings.TrimPrefix(u, "@")
+		if u == userID || strings.EqualFold(u, userName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+)
+
+// state records the last processed message per channel so restarts don't reprocess messages
+type state struct {
+	path     string
+	Channels map[string]string `json:"channels"`
+}
+
+// defaultStatePath returns the state file location under the config directory
+func defaultStatePath() string {
+	return filepath.Join(keychain.ConfigDir(), "watch-state.json")
+}
+
+// loadState reads the state file, returning empty state if it doesn't exist yet
+func loadState(path string) (*state, error) {
+	s := &state{path: path, Channels: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	if s.Channels == nil {
+		s.Channels = make(map[string]string)
+	}
+	return s, nil
+}
+
+// save writes the state file atomically
+func (s *state) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
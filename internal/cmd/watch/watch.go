@@ -0,0 +1,393 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+const (
+	// maxReplyLength keeps command output replies comfortably within Slack's limits
+	maxReplyLength = 3000
+
+	successEmoji = "white_check_mark"
+	failureEmoji = "x"
+)
+
+type watchOptions struct {
+	match     string
+	exec      string
+	users     []string
+	reply     bool
+	react     bool
+	rulesFile string
+	statePath string
+	interval  time.Duration
+	timeout   time.Duration
+	once      bool
+}
+
+// watchEvent reports a triggered rule in JSON mode
+type watchEvent struct {
+	Channel  string `json:"channel"`
+	TS       string `json:"ts"`
+	User     string `json:"user"`
+	Rule     int    `json:"rule"`
+	Command  string `json:"command"`
+	ExitCode int    `json:"exit_code"`
+	Output   string `json:"output"`
+}
+
+// NewCmd creates the watch command
+func NewCmd() *cobra.Command {
+	opts := &watchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch [channel]",
+		Short: "Run commands when matching messages are posted",
+		Long: `Run a command for each new channel message that matches a pattern.
+
+Polls conversations.history and runs --exec for every new message whose text
+matches --match (a regular expression). The message is passed to the command
+through environment variables:
+
+  SLCK_CHANNEL      Channel ID
+  SLCK_TS           Message timestamp
+  SLCK_THREAD_TS    Thread timestamp (empty for top-level messages)
+  SLCK_USER         Author's user ID
+  SLCK_USER_NAME    Author's display name
+  SLCK_TEXT         Message text
+  SLCK_MATCH_<n>    Capture groups from the match pattern
+
+Use --reply to post the command's output as a thread reply, and --react to
+react with ✅ or ❌ depending on the exit code.
+
+The last processed message per channel is recorded in a state file, so
+restarting watch does not run commands for messages it already handled.
+On first run, only messages posted after watch starts are processed.
+
+RULES FILE
+
+Use --rules to watch several channels with different filters:
+
+  rules:
+    - channel: deploys
+      match: 'deploy (\S+) to prod'
+      users: [U01234ABCDE, "@alice"]
+      exec: ./scripts/verify-deploy.sh "$SLCK_MATCH_1"
+      reply: true
+    - channel: alerts
+      match: '(?i)disk full'
+      exec: ./scripts/cleanup.sh
+      react: true
+
+Examples:
+  slck watch deploys --match '^!status' --exec ./status.sh --reply
+  slck watch alerts --match '(?i)error' --exec 'notify-send "$SLCK_TEXT"'
+  slck watch --rules chatops.yaml
+  slck watch --rules chatops.yaml --once   # for cron`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channel := ""
+			if len(args) > 0 {
+				channel = args[0]
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return runWatch(ctx, channel, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.match, "match", "", "Regular expression messages must match (default: all messages)")
+	cmd.Flags().StringVar(&opts.exec, "exec", "", "Command to run for each matching message")
+	cmd.Flags().StringSliceVar(&opts.users, "user", nil, "Only trigger on messages from these users (ID or @display-name)")
+	cmd.Flags().BoolVar(&opts.reply, "reply", false, "Reply in thread with the command's output")
+	cmd.Flags().BoolVar(&opts.react, "react", false, "React with ✅ or ❌ based on the command's exit code")
+	cmd.Flags().StringVar(&opts.rulesFile, "rules", "", "YAML file with watch rules")
+	cmd.Flags().StringVar(&opts.statePath, "state", "", "State file path (default: watch-state.json in the config directory)")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 10*time.Second, "Time between polls")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 5*time.Minute, "Maximum run time for each command")
+	cmd.Flags().BoolVar(&opts.once, "once", false, "Process new messages once and exit")
+
+	return cmd
+}
+
+func runWatch(ctx context.Context, channel string, opts *watchOptions, c *client.Client) error {
+	rules, err := buildRules(channel, opts)
+	if err != nil {
+		return err
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	statePath := opts.statePath
+	if statePath == "" {
+		statePath = defaultStatePath()
+	}
+	st, err := loadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	w := &watcher{
+		client:   c,
+		rules:    rules,
+		state:    st,
+		resolver: client.NewUserResolver(c),
+		timeout:  opts.timeout,
+	}
+
+	// Identify ourselves so replies posted by watch never trigger rules
+	if auth, err := c.AuthTest(); err == nil {
+		w.self = auth.UserID
+	}
+
+	start := client.TimestampFromTime(time.Now())
+	for i := range w.rules {
+		channelID, err := c.ResolveChannel(w.rules[i].Channel)
+		if err != nil {
+			return err
+		}
+		w.rules[i].channelID = channelID
+		if _, seen := st.Channels[channelID]; !seen {
+			st.Channels[channelID] = start
+		}
+		if !slices.Contains(w.channels, channelID) {
+			w.channels = append(w.channels, channelID)
+		}
+	}
+	if err := st.save(); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+
+	for {
+		err := w.poll(ctx)
+		if wait, ok := client.IsRateLimited(err); ok {
+			if !client.SleepContext(ctx, wait) {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if opts.once || !client.SleepContext(ctx, opts.interval) {
+			return nil
+		}
+	}
+}
+
+// buildRules returns the rules from --rules, or a single rule built from flags
+func buildRules(channel string, opts *watchOptions) ([]Rule, error) {
+	if opts.rulesFile != "" {
+		if channel != "" || opts.exec != "" || opts.match != "" {
+			return nil, fmt.Errorf("cannot combine --rules with a channel argument, --match or --exec")
+		}
+		return loadRules(opts.rulesFile)
+	}
+
+	if channel == "" {
+		return nil, fmt.Errorf("a channel argument or --rules file is required")
+	}
+	if opts.exec == "" {
+		return nil, fmt.Errorf("--exec is required")
+	}
+
+	rule := Rule{
+		Channel: channel,
+		Match:   opts.match,
+		Users:   opts.users,
+		Exec:    opts.exec,
+		Reply:   opts.reply,
+		React:   opts.react,
+	}
+	if err := rule.compile(); err != nil {
+		return nil, err
+	}
+	return []Rule{rule}, nil
+}
+
+// watcher holds the polling state for a watch session
+type watcher struct {
+	client   *client.Client
+	rules    []Rule
+	channels []string
+	state    *state
+	resolver *client.UserResolver
+	self     string
+	timeout  time.Duration
+}
+
+// poll processes new messages in every watched channel
+func (w *watcher) poll(ctx context.Context) error {
+	for _, channelID := range w.channels {
+		messages, err := w.client.GetChannelHistorySince(channelID, w.state.Channels[channelID])
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get history for %s", channelID), err)
+		}
+
+		for _, m := range client.Chronological(messages) {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if w.self == "" || m.User != w.self {
+				if err := w.process(ctx, channelID, m); err != nil {
+					if ctx.Err() != nil {
+						// Interrupted, so leave the message to be run after a restart
+						return nil
+					}
+					return err
+				}
+			}
+			w.state.Channels[channelID] = m.TS
+			if err := w.state.save(); err != nil {
+				return fmt.Errorf("writing state file: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// process runs every rule that matches the message
+func (w *watcher) process(ctx context.Context, channelID string, m client.Message) error {
+	userName := w.resolver.Resolve(m.User)
+
+	for i := range w.rules {
+		rule := &w.rules[i]
+		if rule.channelID != channelID {
+			continue
+		}
+		groups, ok := rule.matches(m.Text, m.User, userName)
+		if !ok {
+			continue
+		}
+
+		env := []string{
+			"SLCK_CHANNEL=" + channelID,
+			"SLCK_TS=" + m.TS,
+			"SLCK_THREAD_TS=" + m.ThreadTS,
+			"SLCK_USER=" + m.User,
+			"SLCK_USER_NAME=" + userName,
+			"SLCK_TEXT=" + m.Text,
+		}
+		for n, group := range groups[1:] {
+			env = append(env, fmt.Sprintf("SLCK_MATCH_%d=%s", n+1, group))
+		}
+
+		out, exitCode, runErr := runCommand(ctx, rule.Exec, env, w.timeout)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := w.report(channelID, m, i+1, rule, out, exitCode, runErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// report prints the outcome of a command and optionally replies or reacts in Slack
+func (w *watcher) report(channelID string, m client.Message, ruleNum int, rule *Rule, out string, exitCode int, runErr error) error {
+	if output.IsJSON() {
+		if err := output.PrintJSONLine(watchEvent{
+			Channel:  channelID,
+			TS:       m.TS,
+			User:     m.User,
+			Rule:     ruleNum,
+			Command:  rule.Exec,
+			ExitCode: exitCode,
+			Output:   out,
+		}); err != nil {
+			return err
+		}
+	} else {
+		output.Printf("[%s] %s rule %d matched message %s, exit code %d\n", client.FormatTimestamp(m.TS), channelID, ruleNum, m.TS, exitCode)
+	}
+
+	if rule.Reply {
+		text := strings.TrimSpace(out)
+		if text == "" && runErr != nil {
+			text = fmt.Sprintf("Command failed: %v", runErr)
+		}
+		if text != "" {
+			if utf8.RuneCountInString(text) > maxReplyLength {
+				text = string([]rune(text)[:maxReplyLength-3]) + "..."
+			}
+			threadTS := m.ThreadTS
+			if threadTS == "" {
+				threadTS = m.TS
+			}
+			if _, err := w.client.SendMessage(channelID, text, threadTS, nil, false); err != nil {
+				return client.WrapError("reply with command output", err)
+			}
+		}
+	}
+
+	if rule.React {
+		emoji := successEmoji
+		if exitCode != 0 {
+			emoji = failureEmoji
+		}
+		if err := w.client.AddReaction(channelID, m.TS, emoji); err != nil {
+			return client.WrapError(fmt.Sprintf("add reaction :%s:", emoji), err)
+		}
+	}
+
+	return nil
+}
+
+// runCommand runs command through the shell with extra environment variables,
+// returning its stdout and exit code. Stderr is passed through to the terminal.
+func runCommand(ctx context.Context, command string, env []string, timeout time.Duration) (string, int, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), env...)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return stdout.String(), 0, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return stdout.String(), exitErr.ExitCode(), err
+	}
+	return stdout.String(), -1, err
+}
@@ -0,0 +1,318 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+func writeState(t *testing.T, channels map[string]string) string {
+	path := filepath.Join(t.TempDir(), "state.json")
+	data, err := json.Marshal(map[string]interface{}{"channels": channels})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestBuildRules_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		opts    *watchOptions
+		wantErr string
+	}{
+		{
+			name:    "missing channel",
+			opts:    &watchOptions{exec: "true"},
+			wantErr: "channel argument or --rules",
+		},
+		{
+			name:    "missing exec",
+			channel: "C123",
+			opts:    &watchOptions{},
+			wantErr: "--exec is required",
+		},
+		{
+			name:    "invalid regex",
+			channel: "C123",
+			opts:    &watchOptions{exec: "true", match: "("},
+			wantErr: "invalid match pattern",
+		},
+		{
+			name:    "rules with flags",
+			channel: "C123",
+			opts:    &watchOptions{rulesFile: "rules.yaml"},
+			wantErr: "cannot combine --rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildRules(tt.channel, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	content := `rules:
+  - channel: deploys
+    match: 'deploy (\S+)'
+    users: [U001, "@bob"]
+    exec: echo "$SLCK_MATCH_1"
+    reply: true
+  - channel: alerts
+    exec: ./cleanup.sh
+    react: true
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	rules, err := loadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+
+	groups, ok := rules[0].matches("deploy api now", "U001", "alice")
+	assert.True(t, ok)
+	assert.Equal(t, "api", groups[1])
+
+	_, ok = rules[0].matches("deploy api now", "U003", "carol")
+	assert.False(t, ok, "user filter should reject other users")
+
+	_, ok = rules[0].matches("deploy web", "U002", "Bob")
+	assert.True(t, ok, "user filter should accept display names")
+
+	_, ok = rules[1].matches("anything", "U003", "carol")
+	assert.True(t, ok, "empty match should accept all messages")
+}
+
+func TestLoadRules_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rules:\n  - match: foo\n"), 0600))
+
+	_, err := loadRules(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule 1: channel is required")
+}
+
+func TestRunWatch_ExecReplyAndReact(t *testing.T) {
+	var replies []map[string]interface{}
+	var reactions []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/conversations.history":
+			assert.Equal(t, "1234567890.000000", r.URL.Query().Get("oldest"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.000003", "user": "UBOT", "text": "deploy ignored"},
+					{"ts": "1234567890.000002", "user": "U001", "text": "no match here"},
+					{"ts": "1234567890.000001", "user": "U001", "text": "deploy api"},
+				},
+			})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": "U001", "name": "alice"},
+			})
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			replies = append(replies, body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.000009"})
+		case "/reactions.add":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			reactions = append(reactions, body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	statePath := writeState(t, map[string]string{"C123": "1234567890.000000"})
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &watchOptions{
+		match:     `deploy (\S+)`,
+		exec:      `echo "$SLCK_USER_NAME deployed $SLCK_MATCH_1"`,
+		reply:     true,
+		react:     true,
+		statePath: statePath,
+		interval:  time.Second,
+		timeout:   10 * time.Second,
+		once:      true,
+	}
+
+	err := runWatch(context.Background(), "C123", opts, c)
+	require.NoError(t, err)
+
+	require.Len(t, replies, 1)
+	assert.Equal(t, "alice deployed api", replies[0]["text"])
+	assert.Equal(t, "1234567890.000001", replies[0]["thread_ts"])
+
+	require.Len(t, reactions, 1)
+	assert.Equal(t, successEmoji, reactions[0]["name"])
+	assert.Equal(t, "1234567890.000001", reactions[0]["timestamp"])
+
+	// State advances past every message, including ones that didn't match
+	st, err := loadState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890.000003", st.Channels["C123"])
+}
+
+func TestRunWatch_FailedCommandReactsWithCross(t *testing.T) {
+	var reaction string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "run it"},
+				},
+			})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user": map[string]interface{}{"id": "U001"}})
+		case "/reactions.add":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			reaction = body["name"].(string)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &watchOptions{
+		exec:      "exit 3",
+		react:     true,
+		statePath: writeState(t, map[string]string{"C123": "1234567890.000000"}),
+		interval:  time.Second,
+		once:      true,
+	}
+
+	err := runWatch(context.Background(), "C123", opts, c)
+	require.NoError(t, err)
+	assert.Equal(t, failureEmoji, reaction)
+}
+
+func TestRunWatch_FirstRunSkipsExistingMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/conversations.history":
+			// Without saved state, polling starts from the current time
+			assert.Greater(t, r.URL.Query().Get("oldest"), "1700000000")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": []interface{}{}})
+		}
+	}))
+	defer server.Close()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &watchOptions{exec: "true", statePath: statePath, interval: time.Second, once: true}
+
+	err := runWatch(context.Background(), "C123", opts, c)
+	require.NoError(t, err)
+	assert.FileExists(t, statePath)
+}
+
+func TestRunWatch_InterruptedMessageIsRunAgain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "run it"},
+				},
+			})
+		case "/users.info":
+			// Interrupt watch as the message starts being handled
+			cancel()
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user": map[string]interface{}{"id": "U001"}})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	statePath := writeState(t, map[string]string{"C123": "1234567890.000000"})
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &watchOptions{exec: "sleep 5", react: true, statePath: statePath, interval: time.Second, once: true}
+
+	err := runWatch(ctx, "C123", opts, c)
+	require.NoError(t, err)
+
+	st, err := loadState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890.000000", st.Channels["C123"], "the interrupted message isn't recorded as done")
+}
+
+func TestRunWatch_ReplyTruncatedOnRuneBoundary(t *testing.T) {
+	var reply string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.test":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user_id": "UBOT"})
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1234567890.000001", "user": "U001", "text": "run it"},
+				},
+			})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "user": map[string]interface{}{"id": "U001"}})
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			reply = body["text"].(string)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.000009"})
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &watchOptions{
+		exec:      "yes é | head -n 4000 | tr -d '\\n'",
+		reply:     true,
+		statePath: writeState(t, map[string]string{"C123": "1234567890.000000"}),
+		interval:  time.Second,
+		timeout:   10 * time.Second,
+		once:      true,
+	}
+
+	require.NoError(t, runWatch(context.Background(), "C123", opts, c))
+	assert.True(t, utf8.ValidString(reply), "multi-byte characters aren't split")
+	assert.Equal(t, maxReplyLength, utf8.RuneCountInString(reply))
+	assert.True(t, strings.HasSuffix(reply, "é..."))
+}
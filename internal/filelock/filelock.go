@@ -0,0 +1,48 @@
+// Package filelock serializes updates to slck's local journals between
+// processes with a lock file next to the journal.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockWait is how long to wait for another process to release a lock
+	lockWait = 15 * time.Second
+
+	// lockStale is how old a lock must be to be treated as left behind by a
+	// process that crashed; locks are only held while a journal is written
+	lockStale = 10 * time.Second
+)
+
+// Lock takes the lock file at path, waiting while another process holds it,
+// and returns a function that releases it
+func Lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
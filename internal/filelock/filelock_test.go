@@ -0,0 +1,66 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLock_Excludes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json.lock")
+
+	var mu sync.Mutex
+	holders, maxHolders := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := Lock(path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			holders++
+			if holders > maxHolders {
+				maxHolders = holders
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+			unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxHolders != 1 {
+		t.Errorf("lock was held by %d processes at once, want 1", maxHolders)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestLock_TakesOverStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json.lock")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	unlock()
+}
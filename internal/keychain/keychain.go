@@ -188,6 +188,12 @@ func deleteFromKeychain(account string) error {
 
 // --- Config File (Linux fallback) ---
 
+// ConfigDir returns the directory where slck keeps its configuration and local state.
+// Honors XDG_CONFIG_HOME; defaults to ~/.config/slack-chat-api.
+func ConfigDir() string {
+	return getConfigDir()
+}
+
 func getConfigDir() string {
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
 		return filepath.Join(xdgConfig, "slack-chat-api")
@@ -0,0 +1,235 @@
+// Package mirror keeps a local copy of channel history, written by slck sync
+// and read offline by the slck local commands.
+//
+// Each channel has a directory named after its ID holding channel.json, its
+// sync state, and messages.jsonl, one message per line sorted by timestamp.
+// Thread replies are kept alongside the messages they answer. users.json
+// maps user IDs to names so authors can be shown without the API.
+package mirror
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
+)
+
+const (
+	channelFile  = "channel.json"
+	messagesFile = "messages.jsonl"
+	usersFile    = "users.json"
+)
+
+// DefaultDir returns the mirror location under the config directory
+func DefaultDir() string {
+	return filepath.Join(keychain.ConfigDir(), "mirror")
+}
+
+// Store is a mirror directory
+type Store struct {
+	dir string
+}
+
+// Open returns the mirror in dir. Nothing is read or created until it's used.
+func Open(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Channel is a mirrored channel and how far it has been synced
+type Channel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Checkpoint is the newest top-level message synced; the next sync
+	// fetches history after it
+	Checkpoint string    `json:"checkpoint,omitempty"`
+	SyncedAt   time.Time `json:"synced_at"`
+	Messages   int       `json:"messages"`
+	Replies    int       `json:"replies"`
+}
+
+// IsReply reports whether m is a thread reply rather than a top-level
+// message. Replies also sent to the channel count as replies.
+func IsReply(m client.Message) bool {
+	return m.ThreadTS != "" && m.ThreadTS != m.TS
+}
+
+// Channels returns every mirrored channel, sorted by name
+func (s *Store) Channels() ([]Channel, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mirror: %w", err)
+	}
+
+	var channels []Channel
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		ch, err := s.Channel(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ch != nil {
+			channels = append(channels, *ch)
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Name < channels[j].Name })
+	return channels, nil
+}
+
+// Channel returns a mirrored channel's state, or nil if it hasn't been synced
+func (s *Store) Channel(id string) (*Channel, error) {
+	path := filepath.Join(s.dir, id, channelFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mirror: %w", err)
+	}
+	var ch Channel
+	if err := json.Unmarshal(data, &ch); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &ch, nil
+}
+
+// FindChannel looks up a mirrored channel by ID or name, with or without #
+func (s *Store) FindChannel(channel string) (*Channel, error) {
+	name := strings.TrimPrefix(channel, "#")
+	channels, err := s.Channels()
+	if err != nil {
+		return nil, err
+	}
+	for i := range channels {
+		if channels[i].ID == name || channels[i].Name == name {
+			return &channels[i], nil
+		}
+	}
+	return nil, fmt.Errorf("channel %q hasn't been synced (run: slck sync %s)", channel, channel)
+}
+
+// Messages returns a channel's messages and thread replies, oldest first
+func (s *Store) Messages(id string) ([]client.Message, error) {
+	f, err := os.Open(filepath.Join(s.dir, id, messagesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mirror: %w", err)
+	}
+	defer f.Close()
+
+	var messages []client.Message
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var m client.Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			return nil, fmt.Errorf("parsing %s line %d: %w", f.Name(), line, err)
+		}
+		messages = append(messages, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading mirror: %w", err)
+	}
+	return messages, nil
+}
+
+// Save merges messages into a channel's mirror, replacing stored copies of
+// the same messages, then records the channel's state with updated counts
+func (s *Store) Save(ch *Channel, messages []client.Message) error {
+	stored, err := s.Messages(ch.ID)
+	if err != nil {
+		return err
+	}
+
+	byTS := make(map[string]client.Message, len(stored)+len(messages))
+	for _, m := range stored {
+		byTS[m.TS] = m
+	}
+	for _, m := range messages {
+		byTS[m.TS] = m
+	}
+
+	merged := make([]client.Message, 0, len(byTS))
+	ch.Messages, ch.Replies = 0, 0
+	for _, m := range byTS {
+		merged = append(merged, m)
+		if IsReply(m) {
+			ch.Replies++
+		} else {
+			ch.Messages++
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return client.CompareTimestamps(merged[i].TS, merged[j].TS) < 0 })
+
+	var b strings.Builder
+	for _, m := range merged {
+		line, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+
+	dir := filepath.Join(s.dir, ch.ID)
+	if err := writeFile(filepath.Join(dir, messagesFile), []byte(b.String())); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ch, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, channelFile), data)
+}
+
+// Users returns the mirrored user names, keyed by user ID
+func (s *Store) Users() (map[string]string, error) {
+	users := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(s.dir, usersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return users, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mirror: %w", err)
+	}
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", usersFile, err)
+	}
+	return users, nil
+}
+
+// SaveUsers records user names, keyed by user ID
+func (s *Store) SaveUsers(users map[string]string) error {
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(s.dir, usersFile), data)
+}
+
+// writeFile writes a mirror file atomically, creating its directory
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
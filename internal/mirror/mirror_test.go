@@ -0,0 +1,96 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+func TestStore_SaveMerges(t *testing.T) {
+	s := Open(t.TempDir())
+	ch := &Channel{ID: "C123", Name: "incidents"}
+
+	require.NoError(t, s.Save(ch, []client.Message{
+		{TS: "1700000600.000100", Text: "second"},
+		{TS: "1700000000.000100", Text: "first", ThreadTS: "1700000000.000100", ReplyCount: 1},
+		{TS: "1700000300.000100", Text: "reply", ThreadTS: "1700000000.000100"},
+	}))
+	assert.Equal(t, 2, ch.Messages)
+	assert.Equal(t, 1, ch.Replies)
+
+	// Saving again replaces stored copies instead of duplicating them
+	require.NoError(t, s.Save(ch, []client.Message{
+		{TS: "1700000600.000100", Text: "second (edited)"},
+		{TS: "1700000900.000100", Text: "third"},
+	}))
+	assert.Equal(t, 3, ch.Messages)
+	assert.Equal(t, 1, ch.Replies)
+
+	messages, err := s.Messages("C123")
+	require.NoError(t, err)
+	require.Len(t, messages, 4)
+	var texts []string
+	for _, m := range messages {
+		texts = append(texts, m.Text)
+	}
+	assert.Equal(t, []string{"first", "reply", "second (edited)", "third"}, texts)
+
+	stored, err := s.Channel("C123")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, 3, stored.Messages)
+}
+
+func TestStore_Channels(t *testing.T) {
+	s := Open(t.TempDir())
+
+	channels, err := s.Channels()
+	require.NoError(t, err)
+	assert.Empty(t, channels, "a missing mirror is empty")
+
+	ch, err := s.Channel("C999")
+	require.NoError(t, err)
+	assert.Nil(t, ch)
+
+	require.NoError(t, s.Save(&Channel{ID: "C2", Name: "random"}, nil))
+	require.NoError(t, s.Save(&Channel{ID: "C1", Name: "general"}, nil))
+
+	channels, err = s.Channels()
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, "general", channels[0].Name)
+
+	found, err := s.FindChannel("#random")
+	require.NoError(t, err)
+	assert.Equal(t, "C2", found.ID)
+
+	found, err = s.FindChannel("C1")
+	require.NoError(t, err)
+	assert.Equal(t, "general", found.Name)
+
+	_, err = s.FindChannel("incidents")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slck sync incidents")
+}
+
+func TestStore_Users(t *testing.T) {
+	s := Open(t.TempDir())
+
+	users, err := s.Users()
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	require.NoError(t, s.SaveUsers(map[string]string{"U001": "alice"}))
+	users, err = s.Users()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"U001": "alice"}, users)
+}
+
+func TestIsReply(t *testing.T) {
+	assert.False(t, IsReply(client.Message{TS: "1.1"}))
+	assert.False(t, IsReply(client.Message{TS: "1.1", ThreadTS: "1.1"}))
+	assert.True(t, IsReply(client.Message{TS: "1.2", ThreadTS: "1.1"}))
+}
@@ -0,0 +1,115 @@
+package mrkdwn
+
+// emoji maps common Slack shortcodes to their Unicode characters. Custom
+// workspace emoji and anything not listed here are shown as :name:.
+var emoji = map[string]string{
+	"+1":                       "👍",
+	"-1":                       "👎",
+	"100":                      "💯",
+	"alarm_clock":              "⏰",
+	"arrow_down":               "⬇️",
+	"arrow_left":               "⬅️",
+	"arrow_right":              "➡️",
+	"arrow_up":                 "⬆️",
+	"bar_chart":                "📊",
+	"beers":                    "🍻",
+	"bell":                     "🔔",
+	"blush":                    "😊",
+	"boom":                     "💥",
+	"bug":                      "🐛",
+	"bulb":                     "💡",
+	"calendar":                 "📆",
+	"chart_with_upwards_trend": "📈",
+	"clap":                     "👏",
+	"clipboard":                "📋",
+	"coffee":                   "☕",
+	"construction":             "🚧",
+	"cry":                      "😢",
+	"date":                     "📅",
+	"disappointed":             "😞",
+	"eight":                    "8️⃣",
+	"exclamation":              "❗",
+	"eyes":                     "👀",
+	"facepalm":                 "🤦",
+	"fire":                     "🔥",
+	"five":                     "5️⃣",
+	"four":                     "4️⃣",
+	"gear":                     "⚙️",
+	"ghost":                    "👻",
+	"gift":                     "🎁",
+	"grimacing":                "😬",
+	"grin":                     "😁",
+	"grinning":                 "😀",
+	"heart":                    "❤️",
+	"heart_eyes":               "😍",
+	"heavy_check_mark":         "✔️",
+	"heavy_minus_sign":         "➖",
+	"heavy_plus_sign":          "➕",
+	"hourglass":                "⌛",
+	"hourglass_flowing_sand":   "⏳",
+	"hugging_face":             "🤗",
+	"information_source":       "ℹ️",
+	"joy":                      "😂",
+	"key":                      "🔑",
+	"keycap_ten":               "🔟",
+	"large_blue_circle":        "🔵",
+	"large_green_circle":       "🟢",
+	"large_orange_circle":      "🟠",
+	"large_yellow_circle":      "🟡",
+	"laughing":                 "😆",
+	"link":                     "🔗",
+	"lock":                     "🔒",
+	"mag":                      "🔍",
+	"memo":                     "📝",
+	"muscle":                   "💪",
+	"nine":                     "9️⃣",
+	"no_entry":                 "⛔",
+	"no_entry_sign":            "🚫",
+	"ok":                       "🆗",
+	"ok_hand":                  "👌",
+	"one":                      "1️⃣",
+	"package":                  "📦",
+	"partying_face":            "🥳",
+	"pencil":                   "📝",
+	"pencil2":                  "✏️",
+	"point_down":               "👇",
+	"point_left":               "👈",
+	"point_right":              "👉",
+	"point_up":                 "☝️",
+	"pray":                     "🙏",
+	"pushpin":                  "📌",
+	"question":                 "❓",
+	"raised_hands":             "🙌",
+	"recycle":                  "♻️",
+	"red_circle":               "🔴",
+	"rocket":                   "🚀",
+	"rotating_light":           "🚨",
+	"scream":                   "😱",
+	"seven":                    "7️⃣",
+	"shipit":                   "🐿️",
+	"six":                      "6️⃣",
+	"skull":                    "💀",
+	"slightly_smiling_face":    "🙂",
+	"smile":                    "😄",
+	"smiley":                   "😃",
+	"sob":                      "😭",
+	"sparkles":                 "✨",
+	"speech_balloon":           "💬",
+	"star":                     "⭐",
+	"sunglasses":               "😎",
+	"sweat_smile":              "😅",
+	"tada":                     "🎉",
+	"thinking_face":            "🤔",
+	"three":                    "3️⃣",
+	"thumbsdown":               "👎",
+	"thumbsup":                 "👍",
+	"two":                      "2️⃣",
+	"warning":                  "⚠️",
+	"wave":                     "👋",
+	"white_check_mark":         "✅",
+	"wink":                     "😉",
+	"wrench":                   "🔧",
+	"x":                        "❌",
+	"zap":                      "⚡",
+	"zero":                     "0️⃣",
+}
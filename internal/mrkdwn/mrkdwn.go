@@ -0,0 +1,427 @@
+// Package mrkdwn renders Slack's mrkdwn message markup as terminal text,
+// Markdown or HTML.
+package mrkdwn
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// ANSI styles used when color is enabled
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiItalic = "\x1b[3m"
+	ansiStrike = "\x1b[9m"
+	ansiCyan   = "\x1b[36m"
+	ansiBlue   = "\x1b[34m"
+)
+
+var (
+	// Code, code blocks and <...> entities are rendered on their own, before
+	// the surrounding text is formatted
+	protectedRegex = regexp.MustCompile("```[\\s\\S]*?```|`[^`\\n]+`|<[^<>\\n]+>")
+
+	// :shortcode: emoji, with an optional skin tone modifier
+	emojiRegex = regexp.MustCompile(`:([a-z0-9_+\-']+):(?::skin-tone-\d:)?`)
+)
+
+// placeholderBase is the start of Supplementary Private Use Area-A, used to
+// hold the place of rendered code and entities while the rest is formatted.
+// Slack's search highlighting uses U+E000 and U+E001, so those are avoided.
+const (
+	placeholderBase = 0xF0000
+	maxPlaceholders = 0xFFFE
+)
+
+// target is the kind of output mrkdwn is rendered to
+type target int
+
+const (
+	terminal target = iota
+	markdown
+	htmlTarget
+)
+
+// Renderer converts Slack mrkdwn into readable terminal text, Markdown or HTML.
+type Renderer struct {
+	// ResolveUser returns a display name for a user ID. If nil, IDs are shown.
+	ResolveUser func(id string) string
+	// ResolveChannel returns a name for a channel ID. If nil, IDs are shown.
+	ResolveChannel func(id string) string
+	// Color enables ANSI styling of formatting, code, mentions and links.
+	// Without it, *bold*, _italic_ and `code` markers are left as written.
+	Color bool
+}
+
+// Render converts text from Slack mrkdwn to terminal text.
+func (r *Renderer) Render(text string) string {
+	return r.render(text, terminal)
+}
+
+// Markdown converts text from Slack mrkdwn to Markdown. Lines end in hard
+// breaks, as Slack shows every newline.
+func (r *Renderer) Markdown(text string) string {
+	// A hard break before a blank line does nothing, so it's left out
+	return strings.NewReplacer("  \n  \n", "\n\n", "  \n\n", "\n\n").Replace(r.render(text, markdown))
+}
+
+// HTML converts text from Slack mrkdwn to an HTML fragment. Everything that
+// isn't markup is escaped, so the result is safe to embed in a page.
+func (r *Renderer) HTML(text string) string {
+	return r.render(text, htmlTarget)
+}
+
+func (r *Renderer) render(text string, t target) string {
+	var held []string
+	text = protectedRegex.ReplaceAllStringFunc(text, func(match string) string {
+		if len(held) >= maxPlaceholders {
+			return match
+		}
+		var rendered string
+		if strings.HasPrefix(match, "`") {
+			rendered = r.renderCode(match, t)
+		} else {
+			rendered = r.renderEntity(match[1:len(match)-1], t)
+		}
+		held = append(held, rendered)
+		return string(rune(placeholderBase + len(held) - 1))
+	})
+
+	text = r.renderQuotes(text, t)
+	text = emojiRegex.ReplaceAllStringFunc(text, func(match string) string {
+		name := emojiRegex.FindStringSubmatch(match)[1]
+		if e, ok := emoji[name]; ok {
+			return e
+		}
+		return match
+	})
+
+	switch t {
+	case terminal:
+		if r.Color {
+			text = styleSpans(text, '*', ansiBold, ansiReset)
+			text = styleSpans(text, '_', ansiItalic, ansiReset)
+			text = styleSpans(text, '~', ansiStrike, ansiReset)
+		}
+		text = unescape(text)
+	case markdown:
+		text = styleSpans(text, '*', "**", "**")
+		text = styleSpans(text, '~', "~~", "~~")
+		// A literal < could start an HTML tag, so it stays escaped
+		text = strings.NewReplacer("&lt;", `\<`, "&gt;", ">", "&amp;", "&").Replace(text)
+		text = strings.ReplaceAll(text, "\n", "  \n")
+	case htmlTarget:
+		// Slack has already escaped &, < and >, which is all HTML text needs
+		text = styleSpans(text, '*', "<strong>", "</strong>")
+		text = styleSpans(text, '_', "<em>", "</em>")
+		text = styleSpans(text, '~', "<del>", "</del>")
+		text = strings.ReplaceAll(text, "\n", "<br>\n")
+		text = strings.ReplaceAll(text, "</blockquote><br>\n", "</blockquote>\n")
+	}
+
+	if len(held) == 0 {
+		return text
+	}
+	return restore(text, held)
+}
+
+// restore puts rendered code and entities back in place of their placeholders
+func restore(text string, held []string) string {
+	var out strings.Builder
+	for _, c := range text {
+		i := int(c) - placeholderBase
+		if i >= 0 && i < len(held) {
+			out.WriteString(held[i])
+			continue
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
+
+// renderCode renders a `code` span or ```code block```
+func (r *Renderer) renderCode(code string, t target) string {
+	if t == terminal && !r.Color {
+		return unescape(code)
+	}
+	marker := "`"
+	if strings.HasPrefix(code, "```") {
+		marker = "```"
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(code, marker), marker)
+
+	switch t {
+	case markdown:
+		if marker == "`" {
+			return unescape(code)
+		}
+		return "\n```\n" + strings.Trim(unescape(inner), "\n") + "\n```\n"
+	case htmlTarget:
+		if marker == "`" {
+			return "<code>" + html.EscapeString(unescape(inner)) + "</code>"
+		}
+		return "<pre>" + html.EscapeString(strings.Trim(unescape(inner), "\n")) + "</pre>"
+	}
+	return r.style(ansiCyan, unescape(inner))
+}
+
+// renderEntity renders the inside of a <...> entity: a user, channel or
+// group mention, a broadcast, a date or a link
+func (r *Renderer) renderEntity(entity string, t target) string {
+	target, label, hasLabel := strings.Cut(entity, "|")
+
+	switch {
+	case strings.HasPrefix(target, "@"):
+		id := target[1:]
+		name := label
+		if !hasLabel {
+			name = id
+			if r.ResolveUser != nil {
+				name = r.ResolveUser(id)
+			}
+		}
+		return r.mention("@"+strings.TrimPrefix(name, "@"), t)
+
+	case strings.HasPrefix(target, "#"):
+		id := target[1:]
+		name := label
+		if !hasLabel {
+			name = id
+			if r.ResolveChannel != nil {
+				name = r.ResolveChannel(id)
+			}
+		}
+		return r.mention("#"+strings.TrimPrefix(name, "#"), t)
+
+	case strings.HasPrefix(target, "!subteam^"):
+		name := label
+		if !hasLabel {
+			name = strings.TrimPrefix(target, "!subteam^")
+		}
+		return r.mention("@"+strings.TrimPrefix(name, "@"), t)
+
+	case strings.HasPrefix(target, "!date^"):
+		// <!date^1392734382^{date_short}|Feb 18, 2014> always carries a fallback
+		if t == htmlTarget {
+			return html.EscapeString(unescape(label))
+		}
+		return unescape(label)
+
+	case strings.HasPrefix(target, "!"):
+		name := strings.TrimPrefix(target, "!")
+		if hasLabel {
+			name = strings.TrimPrefix(label, "@")
+		}
+		return r.mention("@"+name, t)
+	}
+
+	url := unescape(target)
+	display := strings.TrimPrefix(url, "mailto:")
+	text := unescape(label)
+	if !hasLabel || text == url || text == display {
+		text = ""
+	}
+
+	switch t {
+	case markdown:
+		if text == "" {
+			return "<" + url + ">"
+		}
+		return "[" + text + "](" + url + ")"
+	case htmlTarget:
+		if text == "" {
+			text = display
+		}
+		if !linkable(url) {
+			return html.EscapeString(text)
+		}
+		return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(text) + "</a>"
+	}
+	if text == "" {
+		return r.style(ansiBlue, display)
+	}
+	return text + " " + r.style(ansiDim, "("+display+")")
+}
+
+// linkable reports whether url is safe to link to from HTML: web and mail
+// links are, script URLs and the like aren't
+func linkable(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "mailto:")
+}
+
+// mention renders a user, channel or group mention
+func (r *Renderer) mention(name string, t target) string {
+	switch t {
+	case markdown:
+		return "**" + name + "**"
+	case htmlTarget:
+		return `<span class="mention">` + html.EscapeString(name) + "</span>"
+	}
+	return r.style(ansiBold+ansiBlue, name)
+}
+
+// renderQuotes restyles "> " block quotes. Slack escapes the marker as &gt;.
+func (r *Renderer) renderQuotes(text string, t target) string {
+	if !strings.Contains(text, "&gt;") {
+		return text
+	}
+	if t == htmlTarget {
+		return htmlQuotes(text)
+	}
+	bar := "> "
+	if t == terminal && r.Color {
+		bar = ansiDim + "│" + ansiReset + " "
+	}
+
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	quoted := false
+	for _, line := range lines {
+		wasQuoted := quoted
+		quoted = true
+		if rest, ok := strings.CutPrefix(line, "&gt; "); ok {
+			line = bar + rest
+		} else if line == "&gt;" {
+			line = strings.TrimSpace(bar)
+		} else {
+			quoted = false
+		}
+		// In Markdown a line straight after a quote would continue it
+		if t == markdown && wasQuoted && !quoted {
+			out = append(out, "")
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// htmlQuotes wraps each run of quoted lines in a blockquote element
+func htmlQuotes(text string) string {
+	var out []string
+	var quote []string
+	flush := func() {
+		if len(quote) > 0 {
+			out = append(out, "<blockquote>"+strings.Join(quote, "\n")+"</blockquote>")
+			quote = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if rest, ok := strings.CutPrefix(line, "&gt; "); ok {
+			quote = append(quote, rest)
+		} else if line == "&gt;" {
+			quote = append(quote, "")
+		} else {
+			flush()
+			out = append(out, line)
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// style wraps s in an ANSI style when color is enabled
+func (r *Renderer) style(code, s string) string {
+	if !r.Color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// styleSpans replaces the markers around spans (*bold*, _italic_, ~strike~)
+// with open and close. Like Slack, a span must open at a word boundary, must
+// not start or end with a space and must close on the same line.
+func styleSpans(text string, marker byte, open, close string) string {
+	if strings.IndexByte(text, marker) < 0 {
+		return text
+	}
+
+	var out strings.Builder
+	i := 0
+	for i < len(text) {
+		if text[i] != marker || !boundaryBefore(text, i) {
+			out.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := closingMarker(text, i, marker)
+		if end < 0 {
+			out.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		out.WriteString(open + text[i+1:end] + close)
+		i = end + 1
+	}
+	return out.String()
+}
+
+// closingMarker returns the index of the marker closing a span opened at
+// start, or -1 if there isn't one
+func closingMarker(text string, start int, marker byte) int {
+	if start+1 >= len(text) || text[start+1] == ' ' || text[start+1] == marker {
+		return -1
+	}
+	for j := start + 2; j < len(text); j++ {
+		switch text[j] {
+		case '\n':
+			return -1
+		case marker:
+			if text[j-1] != ' ' && boundaryAfter(text, j) {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// boundaryBefore reports whether the character before i isn't part of a word
+func boundaryBefore(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	c, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWordRune(c)
+}
+
+// boundaryAfter reports whether the character after i isn't part of a word
+func boundaryAfter(text string, i int) bool {
+	if i+1 >= len(text) {
+		return true
+	}
+	c, _ := utf8.DecodeRuneInString(text[i+1:])
+	return !isWordRune(c)
+}
+
+func isWordRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// unescape reverses Slack's escaping of &, < and >
+func unescape(s string) string {
+	if !strings.Contains(s, "&") {
+		return s
+	}
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(s)
+}
+
+// NewRenderer returns a renderer that names users with users and looks up
+// channel names with c. Color follows the output settings.
+func NewRenderer(c *client.Client, users *client.UserResolver) *Renderer {
+	channels := client.NewChannelResolver(c)
+	return &Renderer{
+		ResolveUser:    users.Resolve,
+		ResolveChannel: channels.Resolve,
+		Color:          output.ColorEnabled(),
+	}
+}
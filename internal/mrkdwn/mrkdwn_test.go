@@ -0,0 +1,152 @@
+package mrkdwn
+
+import (
+	"testing"
+)
+
+func testRenderer(color bool) *Renderer {
+	return &Renderer{
+		ResolveUser: func(id string) string {
+			if id == "U001" {
+				return "alice"
+			}
+			return id
+		},
+		ResolveChannel: func(id string) string {
+			if id == "C001" {
+				return "deploys"
+			}
+			return id
+		},
+		Color: color,
+	}
+}
+
+func TestRender_Plain(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "hello world", "hello world"},
+		{"user mention", "hi <@U001>", "hi @alice"},
+		{"user mention with label", "hi <@U002|bob>", "hi @bob"},
+		{"unknown user", "hi <@U999>", "hi @U999"},
+		{"channel with label", "see <#C002|general>", "see #general"},
+		{"channel without label", "see <#C001>", "see #deploys"},
+		{"broadcast", "<!here> and <!channel>", "@here and @channel"},
+		{"user group", "<!subteam^S001|@oncall> and <!subteam^S002>", "@oncall and @S002"},
+		{"date", "due <!date^1392734382^{date_short}|Feb 18, 2014>", "due Feb 18, 2014"},
+		{"bare link", "<https://example.com>", "https://example.com"},
+		{"labelled link", "<https://example.com/a_b_c|the docs>", "the docs (https://example.com/a_b_c)"},
+		{"mailto", "<mailto:bob@example.com|bob@example.com>", "bob@example.com"},
+		{"html entities", "a &amp; b &lt;c&gt;", "a & b <c>"},
+		{"escaped entity not parsed", "&lt;@U001&gt;", "<@U001>"},
+		{"emoji", "ship it :rocket: :+1::skin-tone-3:", "ship it 🚀 👍"},
+		{"unknown emoji", ":partyparrot:", ":partyparrot:"},
+		{"formatting kept without color", "*bold* _it_ ~no~", "*bold* _it_ ~no~"},
+		{"code keeps contents", "run `a &amp;&amp; :rocket:`", "run `a && :rocket:`"},
+		{"quote", "&gt; quoted\nnot", "> quoted\nnot"},
+	}
+
+	r := testRenderer(false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Render(tt.input); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_Color(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bold", "a *bold* b", "a " + ansiBold + "bold" + ansiReset + " b"},
+		{"italic", "_it_", ansiItalic + "it" + ansiReset},
+		{"strike", "~gone~", ansiStrike + "gone" + ansiReset},
+		{"not a span", "2 * 3 * 4 and snake_case_name", "2 * 3 * 4 and snake_case_name"},
+		{"bold around mention", "*by <@U001>*", ansiBold + "by " + ansiBold + ansiBlue + "@alice" + ansiReset + ansiReset},
+		{"code", "`x_y_z`", ansiCyan + "x_y_z" + ansiReset},
+		{"code block", "```\n*not bold*\n```", ansiCyan + "\n*not bold*\n" + ansiReset},
+		{"link", "<https://example.com|docs>", "docs " + ansiDim + "(https://example.com)" + ansiReset},
+		{"quote", "&gt; hi", ansiDim + "│" + ansiReset + " hi"},
+	}
+
+	r := testRenderer(true)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Render(tt.input); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_NilResolvers(t *testing.T) {
+	r := &Renderer{}
+	if got := r.Render("<@U001> in <#C001>"); got != "@U001 in #C001" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text", "hello world", "hello world"},
+		{"mention", "hi <@U001> in <#C001>", "hi **@alice** in **#deploys**"},
+		{"bare link", "<https://example.com>", "<https://example.com>"},
+		{"labelled link", "<https://example.com|the docs>", "[the docs](https://example.com)"},
+		{"formatting", "*bold* _it_ ~no~", "**bold** _it_ ~~no~~"},
+		{"line breaks", "one\ntwo", "one  \ntwo"},
+		{"html stays text", "&lt;div&gt; a &amp; b", `\<div> a & b`},
+		{"inline code", "run `a &amp;&amp; *b*`", "run `a && *b*`"},
+		{"code block", "see:\n```\nx := 1\ny := 2```", "see:\n\n```\nx := 1\ny := 2\n```\n"},
+		{"quote ends before text", "&gt; quoted\nnot", "> quoted\n\nnot"},
+		{"emoji", "ship it :rocket:", "ship it 🚀"},
+	}
+
+	r := testRenderer(false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Markdown(tt.input); got != tt.want {
+				t.Errorf("Markdown(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"escaped text stays escaped", "a &amp; b &lt;script&gt;", "a &amp; b &lt;script&gt;"},
+		{"mention", "hi <@U001>", `hi <span class="mention">@alice</span>`},
+		{"link", `<https://example.com/?a=1&amp;b="2"|the "docs">`, `<a href="https://example.com/?a=1&amp;b=&#34;2&#34;">the &#34;docs&#34;</a>`},
+		{"bare link", "<https://example.com>", `<a href="https://example.com">https://example.com</a>`},
+		{"script link not linked", "<javascript:alert(1)|click>", "click"},
+		{"formatting", "*bold* _it_ ~no~", "<strong>bold</strong> <em>it</em> <del>no</del>"},
+		{"line breaks", "one\ntwo", "one<br>\ntwo"},
+		{"code", "run `a &lt;b&gt;` now", "run <code>a &lt;b&gt;</code> now"},
+		{"code block", "```\n*x* <y>\n```", "<pre>*x* &lt;y&gt;</pre>"},
+		{"quote", "&gt; one\n&gt; two\nafter", "<blockquote>one<br>\ntwo</blockquote>\nafter"},
+		{"emoji", ":tada:", "🎉"},
+	}
+
+	r := testRenderer(false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.HTML(tt.input); got != tt.want {
+				t.Errorf("HTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 // Format represents the output format
@@ -37,6 +38,28 @@ func IsJSON() bool {
 	return OutputFormat == FormatJSON || JSON
 }
 
+// IsTable returns true if output format is table
+func IsTable() bool {
+	return OutputFormat == FormatTable && !JSON
+}
+
+// ColorEnabled returns true if ANSI color should be used: color hasn't been
+// turned off with --no-color or NO_COLOR, and output goes to a terminal
+func ColorEnabled() bool {
+	if NoColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := Writer.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // PrintJSON outputs data as formatted JSON
 func PrintJSON(data interface{}) error {
 	enc := json.NewEncoder(Writer)
@@ -44,6 +67,11 @@ func PrintJSON(data interface{}) error {
 	return enc.Encode(data)
 }
 
+// PrintJSONLine outputs data as a single line of JSON (for NDJSON streams)
+func PrintJSONLine(data interface{}) error {
+	return json.NewEncoder(Writer).Encode(data)
+}
+
 // Printf outputs a formatted string
 func Printf(format string, args ...interface{}) {
 	_, _ = fmt.Fprintf(Writer, format, args...)
@@ -114,6 +142,15 @@ func KeyValue(key string, value interface{}) {
 	_, _ = fmt.Fprintf(Writer, "%-12s  %v\n", key+":", value)
 }
 
+// Truncate shortens a string to maxLen runes, replacing newlines with spaces
+func Truncate(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	return string([]rune(s)[:maxLen-3]) + "..."
+}
+
 // ValidFormats returns the list of valid output formats for flag validation
 func ValidFormats() []string {
 	return []string{string(FormatText), string(FormatJSON), string(FormatTable)}
@@ -0,0 +1,21 @@
+package output
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"line one\nline two", 20, "line one line two"},
+		{"a long message body", 10, "a long ..."},
+		{"héllo wörld ünïcode", 10, "héllo w..."},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.max); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
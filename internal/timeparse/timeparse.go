@@ -0,0 +1,181 @@
+// Package timeparse parses the human-readable times accepted by date and time flags.
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+// Syntax describes the accepted formats, for use in command help text
+const Syntax = `  in 2 hours, in 30 minutes   Relative to now ("in" is optional)
+  2h, 30m, 3d, 1w              Short relative form
+  3 days ago, 1w ago           Relative to now, in the past
+  now, today, tomorrow 9am     Named days, with an optional time of day
+  yesterday 17:30
+  2025-06-01                   Local midnight on a date
+  2025-06-01 14:00             Local date and time
+  2025-06-01T14:00:00Z         RFC3339
+  1748786400                   Unix timestamp`
+
+var (
+	relativeRegex  = regexp.MustCompile(`^(?:in\s+)?\+?(\d+)\s*([a-z]+)(\s+ago)?$`)
+	clockRegex     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	unixRegex      = regexp.MustCompile(`^\d{9,10}(?:\.\d+)?$`)
+	absoluteLayout = []string{
+		time.RFC3339,
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+)
+
+var units = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// Parse converts a relative or absolute time expression into a time, relative to now.
+// See Syntax for the accepted formats. Dates without a zone are in now's location.
+func Parse(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	if s == "now" {
+		return now, nil
+	}
+
+	if m := relativeRegex.FindStringSubmatch(s); m != nil {
+		unit, ok := units[m[2]]
+		if !ok {
+			return time.Time{}, fmt.Errorf("invalid time %q: unknown unit %q", input, m[2])
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", input, err)
+		}
+		d := time.Duration(n) * unit
+		if m[3] != "" {
+			if strings.HasPrefix(s, "in ") || strings.HasPrefix(s, "+") {
+				return time.Time{}, fmt.Errorf("invalid time %q: cannot combine \"in\" and \"ago\"", input)
+			}
+			d = -d
+		}
+		return now.Add(d), nil
+	}
+
+	if t, ok, err := parseNamedDay(s, now); ok || err != nil {
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %w", input, err)
+		}
+		return t, nil
+	}
+
+	if unixRegex.MatchString(s) {
+		sec, _ := strconv.ParseInt(strings.SplitN(s, ".", 2)[0], 10, 64)
+		return time.Unix(sec, 0).In(now.Location()), nil
+	}
+
+	for _, layout := range absoluteLayout {
+		raw := strings.TrimSpace(input)
+		if t, err := time.ParseInLocation(layout, raw, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a relative time like \"in 2 hours\" or \"3d ago\", or a date like 2025-06-01 or RFC3339", input)
+}
+
+// Bound converts a flag value such as --since or --until to a Slack timestamp,
+// for use as a history bound. Slack timestamps and message URLs are used as-is;
+// anything else is parsed as a time. An empty value gives an empty bound.
+func Bound(value, flag string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if validate.Timestamp(value) == nil {
+		return validate.NormalizeTimestamp(value), nil
+	}
+
+	t, err := Parse(value, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", flag, err)
+	}
+	return fmt.Sprintf("%d.000000", t.Unix()), nil
+}
+
+// parseNamedDay handles today, tomorrow and yesterday with an optional time of day
+func parseNamedDay(s string, now time.Time) (time.Time, bool, error) {
+	day, clock, _ := strings.Cut(s, " ")
+	clock = strings.TrimPrefix(clock, "at ")
+
+	var offset int
+	switch day {
+	case "today":
+		offset = 0
+	case "tomorrow":
+		offset = 1
+	case "yesterday":
+		offset = -1
+	default:
+		return time.Time{}, false, nil
+	}
+
+	y, mo, d := now.Date()
+	t := time.Date(y, mo, d+offset, 0, 0, 0, 0, now.Location())
+	if clock == "" {
+		if day == "today" {
+			return now, true, nil
+		}
+		// A bare "tomorrow" or "yesterday" keeps the current time of day
+		return t.Add(now.Sub(time.Date(y, mo, d, 0, 0, 0, 0, now.Location()))), true, nil
+	}
+
+	hour, minute, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, true, err
+	}
+	return t.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute), true, nil
+}
+
+// parseClock parses a time of day such as 9am, 5:30pm or 17:30
+func parseClock(s string) (hour, minute int, err error) {
+	m := clockRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid time of day %q", s)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if m[2] == "" {
+			return 0, 0, fmt.Errorf("invalid time of day %q: use 9am or 09:00", s)
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return hour, minute, nil
+}
@@ -0,0 +1,88 @@
+package timeparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2025, 6, 1, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"now", "now", now, false},
+		{"in hours", "in 2 hours", now.Add(2 * time.Hour), false},
+		{"without in", "30 minutes", now.Add(30 * time.Minute), false},
+		{"short form", "2h", now.Add(2 * time.Hour), false},
+		{"plus prefix", "+15m", now.Add(15 * time.Minute), false},
+		{"weeks", "in 1 week", now.Add(7 * 24 * time.Hour), false},
+		{"ago", "3 days ago", now.Add(-3 * 24 * time.Hour), false},
+		{"short ago", "1w ago", now.Add(-7 * 24 * time.Hour), false},
+		{"mixed case", "In 2 Hours", now.Add(2 * time.Hour), false},
+		{"today", "today", now, false},
+		{"tomorrow", "tomorrow", now.Add(24 * time.Hour), false},
+		{"tomorrow am", "tomorrow 9am", time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), false},
+		{"tomorrow at pm", "tomorrow at 5:30pm", time.Date(2025, 6, 2, 17, 30, 0, 0, time.UTC), false},
+		{"yesterday 24h", "yesterday 17:30", time.Date(2025, 5, 31, 17, 30, 0, 0, time.UTC), false},
+		{"twelve am", "today 12am", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"date", "2025-07-04", time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC), false},
+		{"date and time", "2025-07-04 09:15", time.Date(2025, 7, 4, 9, 15, 0, 0, time.UTC), false},
+		{"rfc3339", "2025-07-04T09:15:00-05:00", time.Date(2025, 7, 4, 14, 15, 0, 0, time.UTC), false},
+		{"unix", "1748786400", time.Unix(1748786400, 0).UTC(), false},
+		{"slack timestamp", "1748786400.123456", time.Unix(1748786400, 0).UTC(), false},
+		{"empty", "", time.Time{}, true},
+		{"unknown unit", "in 2 fortnights", time.Time{}, true},
+		{"in and ago", "in 2 hours ago", time.Time{}, true},
+		{"bare hour", "tomorrow 9", time.Time{}, true},
+		{"bad clock", "tomorrow 13pm", time.Time{}, true},
+		{"garbage", "next tuesday-ish", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBound(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"timestamp", "1234567890.123456", "1234567890.123456", false},
+		{"p-prefixed", "p1700000000123456", "1700000000.123456", false},
+		{"message url", "https://workspace.slack.com/archives/C123/p1234567890123456", "1234567890.123456", false},
+		{"rfc3339", "2025-06-01T00:00:00Z", "1748736000.000000", false},
+		{"garbage", "sometime", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bound(tt.input, "--since")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "--since") {
+				t.Errorf("Bound(%q) error = %v, want it to name the flag", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Bound(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package transcript
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// htmlMessage is a message as the HTML template shows it. Body, attachments
+// and reaction emoji are already-rendered HTML.
+type htmlMessage struct {
+	Author      string
+	Time        string
+	Edited      bool
+	Body        template.HTML
+	Attachments []template.HTML
+	Files       []htmlFile
+	Reactions   []htmlReaction
+	Replies     []htmlMessage
+}
+
+type htmlFile struct {
+	Name string
+	URL  string
+}
+
+type htmlReaction struct {
+	Emoji template.HTML
+	Name  string
+	Count int
+}
+
+// htmlPage is a single self-contained file, so it can be attached to a
+// ticket or opened offline: styles are inline and nothing is loaded
+var htmlPage = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; font-size: 15px; line-height: 1.45; color: #1d1c1d; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; }
+h1 { font-size: 1.5rem; margin-bottom: 0.25rem; }
+.summary { color: #616061; margin-top: 0; }
+.message { margin: 1.25rem 0; }
+.author { font-weight: 700; }
+.time, .edited { color: #616061; font-size: 0.85em; margin-left: 0.4rem; }
+.body { margin-top: 0.2rem; overflow-wrap: anywhere; }
+.replies { margin: 0.75rem 0 0 0.5rem; padding-left: 1rem; border-left: 3px solid #ddd; }
+.replies .message { margin: 0.75rem 0; }
+.mention { color: #1264a3; background: #e8f5fa; border-radius: 3px; padding: 0 2px; }
+code { font-family: SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.85em; color: #c01343; background: #f6f6f6; border: 1px solid #e1e1e1; border-radius: 3px; padding: 0 3px; }
+pre { font-family: SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.85em; background: #f6f6f6; border: 1px solid #e1e1e1; border-radius: 4px; padding: 0.5rem 0.75rem; overflow-x: auto; white-space: pre-wrap; }
+blockquote { margin: 0.25rem 0; padding-left: 0.75rem; border-left: 4px solid #ddd; color: #454245; }
+.attachment { margin: 0.4rem 0; padding-left: 0.75rem; border-left: 4px solid #e1e1e1; }
+.files { margin: 0.4rem 0 0; padding: 0; list-style: none; }
+.reactions { margin-top: 0.4rem; }
+.reaction { display: inline-block; border: 1px solid #ddd; border-radius: 1rem; padding: 0 0.5rem; margin-right: 0.25rem; font-size: 0.85em; }
+a { color: #1264a3; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="summary">{{.Summary}}</p>
+{{range .Messages}}{{template "message" .}}{{end}}</body>
+</html>
+{{define "message"}}<div class="message">
+<div><span class="author">{{.Author}}</span><span class="time">{{.Time}}</span>{{if .Edited}}<span class="edited">(edited)</span>{{end}}</div>
+{{if .Body}}<div class="body">{{.Body}}</div>
+{{end}}{{range .Attachments}}<div class="attachment">{{.}}</div>
+{{end}}{{if .Files}}<ul class="files">{{range .Files}}<li>📎 {{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</li>{{end}}</ul>
+{{end}}{{if .Reactions}}<div class="reactions">{{range .Reactions}}<span class="reaction" title=":{{.Name}}:">{{.Emoji}} {{.Count}}</span>{{end}}</div>
+{{end}}{{if .Replies}}<div class="replies">
+{{range .Replies}}{{template "message" .}}{{end}}</div>
+{{end}}</div>
+{{end}}`))
+
+// WriteHTML writes the transcript as a single HTML file with inline styles.
+// Replies are indented under the message they answer.
+func (t *Transcript) WriteHTML(w io.Writer) error {
+	messages := make([]htmlMessage, 0, len(t.Threads))
+	for _, th := range t.Threads {
+		m := t.htmlMessage(th.Message)
+		for _, reply := range th.Replies {
+			m.Replies = append(m.Replies, t.htmlMessage(reply))
+		}
+		messages = append(messages, m)
+	}
+
+	return htmlPage.Execute(w, struct {
+		Title    string
+		Summary  string
+		Messages []htmlMessage
+	}{t.Title, t.summary(), messages})
+}
+
+func (t *Transcript) htmlMessage(m client.Message) htmlMessage {
+	hm := htmlMessage{
+		Author: t.Users.Author(m),
+		Time:   t.time(m.TS),
+		Edited: m.Edited != nil,
+		// The renderer escapes everything that isn't markup it produced
+		Body: template.HTML(t.Renderer.HTML(strings.TrimSpace(m.Text))),
+	}
+	for _, a := range m.Attachments {
+		if line := t.htmlAttachment(a); line != "" {
+			hm.Attachments = append(hm.Attachments, line)
+		}
+	}
+	for _, f := range m.Files {
+		name, link := fileLink(f)
+		hm.Files = append(hm.Files, htmlFile{Name: name, URL: link})
+	}
+	for _, r := range m.Reactions {
+		hm.Reactions = append(hm.Reactions, htmlReaction{
+			Emoji: template.HTML(t.Renderer.HTML(":" + r.Name + ":")),
+			Name:  r.Name,
+			Count: r.Count,
+		})
+	}
+	return hm
+}
+
+// htmlAttachment renders a legacy attachment's title, text and fields
+func (t *Transcript) htmlAttachment(a client.Attachment) template.HTML {
+	var parts []string
+	if a.Title != "" {
+		title := template.HTMLEscapeString(a.Title)
+		if a.TitleLink != "" {
+			// Render the link as mrkdwn, escaped the way Slack escapes text,
+			// so unsafe URLs aren't linked
+			escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", " ")
+			title = t.Renderer.HTML("<" + escape.Replace(a.TitleLink) + "|" + escape.Replace(a.Title) + ">")
+		}
+		parts = append(parts, "<strong>"+title+"</strong>")
+	}
+	if a.Text != "" {
+		parts = append(parts, t.Renderer.HTML(a.Text))
+	} else if a.Title == "" && a.Fallback != "" {
+		parts = append(parts, template.HTMLEscapeString(a.Fallback))
+	}
+	for _, f := range a.Fields {
+		parts = append(parts, template.HTMLEscapeString(f.Title)+": "+t.Renderer.HTML(f.Value))
+	}
+	return template.HTML(strings.Join(parts, "<br>\n"))
+}
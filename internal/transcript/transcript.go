@@ -0,0 +1,201 @@
+// Package transcript writes Slack conversations as readable Markdown or
+// HTML documents, with names resolved and thread replies nested under the
+// message they answer.
+package transcript
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+)
+
+// timeLayout is how message times are shown, in the transcript's location
+const timeLayout = "2006-01-02 15:04 MST"
+
+// Thread is a top-level message and the replies to it, oldest first
+type Thread struct {
+	Message client.Message
+	Replies []client.Message
+}
+
+// NewThread makes a thread from conversations.replies output, which lists
+// the parent first
+func NewThread(messages []client.Message) Thread {
+	if len(messages) == 0 {
+		return Thread{}
+	}
+	return Thread{Message: messages[0], Replies: messages[1:]}
+}
+
+// Transcript is a conversation ready to be written as a document
+type Transcript struct {
+	// Title heads the document, e.g. "#incident-42"
+	Title string
+	// Threads are the conversation's top-level messages, oldest first
+	Threads []Thread
+	// Users names message authors
+	Users *client.UserResolver
+	// Renderer converts message text; its Color setting is ignored
+	Renderer *mrkdwn.Renderer
+	// Location is the time zone times are shown in (default: local time)
+	Location *time.Location
+	// Generated is when the transcript was made (default: now)
+	Generated time.Time
+}
+
+// counts returns the number of top-level messages and replies
+func (t *Transcript) counts() (messages, replies int) {
+	for _, th := range t.Threads {
+		messages++
+		replies += len(th.Replies)
+	}
+	return messages, replies
+}
+
+// summary describes when the transcript was made and what it holds
+func (t *Transcript) summary() string {
+	generated := t.Generated
+	if generated.IsZero() {
+		generated = time.Now()
+	}
+	messages, replies := t.counts()
+	s := fmt.Sprintf("Exported from Slack on %s · %d %s", t.in(generated).Format(timeLayout), messages, plural(messages, "message"))
+	if replies > 0 {
+		s += fmt.Sprintf(", %d %s", replies, plural(replies, "reply"))
+	}
+	return s
+}
+
+// time formats a Slack timestamp in the transcript's location
+func (t *Transcript) time(ts string) string {
+	sec, err := strconv.ParseInt(strings.SplitN(ts, ".", 2)[0], 10, 64)
+	if err != nil {
+		return ts
+	}
+	return t.in(time.Unix(sec, 0)).Format(timeLayout)
+}
+
+func (t *Transcript) in(tm time.Time) time.Time {
+	if t.Location != nil {
+		return tm.In(t.Location)
+	}
+	return tm.Local()
+}
+
+// WriteMarkdown writes the transcript as a Markdown document. Replies are
+// block-quoted under the message they answer.
+func (t *Transcript) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# " + t.Title + "\n\n_" + t.summary() + "_\n")
+
+	for _, th := range t.Threads {
+		b.WriteString("\n" + t.markdownMessage(th.Message) + "\n")
+		for _, reply := range th.Replies {
+			b.WriteString(">\n" + quote(t.markdownMessage(reply)) + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownMessage renders one message: a heading line with the author and
+// time, the text, then attachments, files and reactions
+func (t *Transcript) markdownMessage(m client.Message) string {
+	heading := fmt.Sprintf("**%s** · %s", t.Users.Author(m), t.time(m.TS))
+	if m.Edited != nil {
+		heading += " _(edited)_"
+	}
+	parts := []string{heading}
+
+	if text := strings.TrimSpace(t.Renderer.Markdown(m.Text)); text != "" {
+		parts = append(parts, text)
+	}
+	for _, a := range m.Attachments {
+		if line := t.markdownAttachment(a); line != "" {
+			parts = append(parts, "> "+line)
+		}
+	}
+	if len(m.Files) > 0 {
+		files := make([]string, 0, len(m.Files))
+		for _, f := range m.Files {
+			name, link := fileLink(f)
+			if link == "" {
+				files = append(files, "📎 "+name)
+			} else {
+				files = append(files, "📎 ["+name+"]("+link+")")
+			}
+		}
+		parts = append(parts, strings.Join(files, "  \n"))
+	}
+	if len(m.Reactions) > 0 {
+		reactions := make([]string, 0, len(m.Reactions))
+		for _, r := range m.Reactions {
+			reactions = append(reactions, fmt.Sprintf("%s %d", t.Renderer.Markdown(":"+r.Name+":"), r.Count))
+		}
+		parts = append(parts, strings.Join(reactions, " · "))
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// markdownAttachment summarizes a legacy attachment on a single line
+func (t *Transcript) markdownAttachment(a client.Attachment) string {
+	var parts []string
+	switch {
+	case a.Title != "" && a.TitleLink != "":
+		parts = append(parts, "**["+a.Title+"]("+a.TitleLink+")**")
+	case a.Title != "":
+		parts = append(parts, "**"+a.Title+"**")
+	}
+	if a.Text != "" {
+		parts = append(parts, t.Renderer.Markdown(a.Text))
+	} else if a.Title == "" && a.Fallback != "" {
+		parts = append(parts, a.Fallback)
+	}
+	for _, f := range a.Fields {
+		parts = append(parts, f.Title+": "+t.Renderer.Markdown(f.Value))
+	}
+	return strings.ReplaceAll(strings.Join(parts, " — "), "  \n", " ")
+}
+
+// quote block-quotes every line of a Markdown fragment
+func quote(s string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fileLink returns a shared file's name and the link to open it
+func fileLink(f client.File) (name, link string) {
+	name = f.Title
+	if name == "" {
+		name = f.Name
+	}
+	link = f.Permalink
+	if link == "" {
+		link = f.URLPrivate
+	}
+	return name, link
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	if strings.HasSuffix(word, "y") {
+		return strings.TrimSuffix(word, "y") + "ies"
+	}
+	return word + "s"
+}
@@ -0,0 +1,120 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/mrkdwn"
+)
+
+func testTranscript() *Transcript {
+	parent := client.Message{
+		Username: "alice",
+		Text:     "Deploy failed, see <@U002>:\n```\nexit status 1\n```",
+		TS:       "1700000000.000100",
+		Edited:   &client.Edited{User: "U001", TS: "1700000100.000000"},
+		Reactions: []client.Reaction{
+			{Name: "eyes", Count: 2},
+		},
+	}
+	reply := client.Message{
+		Username: "bob",
+		Text:     "Rolled back <https://ci.example.com/42|build 42>",
+		TS:       "1700000300.000200",
+		Files: []client.File{
+			{Name: "deploy.log", Permalink: "https://files.example.com/F1"},
+		},
+	}
+	other := client.Message{Username: "carol", Text: "a &lt;b&gt; c", TS: "1700003600.000300"}
+
+	return &Transcript{
+		Title:   "#incidents",
+		Threads: []Thread{NewThread([]client.Message{parent, reply}), {Message: other}},
+		Users:   client.NewUserResolver(nil),
+		Renderer: &mrkdwn.Renderer{
+			ResolveUser: func(id string) string { return "bob" },
+			Color:       true,
+		},
+		Location:  time.UTC,
+		Generated: time.Date(2023, 11, 15, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, testTranscript().WriteMarkdown(&b))
+
+	assert.Equal(t, `# #incidents
+
+_Exported from Slack on 2023-11-15 09:00 UTC · 2 messages, 1 reply_
+
+**alice** · 2023-11-14 22:13 UTC _(edited)_
+
+Deploy failed, see **@bob**:
+
+`+"```"+`
+exit status 1
+`+"```"+`
+
+👀 2
+
+>
+> **bob** · 2023-11-14 22:18 UTC
+>
+> Rolled back [build 42](https://ci.example.com/42)
+>
+> 📎 [deploy.log](https://files.example.com/F1)
+
+**carol** · 2023-11-14 23:13 UTC
+
+a \<b> c
+
+`, b.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, testTranscript().WriteHTML(&b))
+	page := b.String()
+
+	assert.True(t, strings.HasPrefix(page, "<!DOCTYPE html>"))
+	assert.Contains(t, page, "<style>")
+	assert.NotContains(t, page, "<link", "the page must not load anything")
+	assert.NotContains(t, page, "<script")
+	assert.NotContains(t, page, "\x1b[", "color is ignored")
+
+	assert.Contains(t, page, `<span class="author">alice</span><span class="time">2023-11-14 22:13 UTC</span><span class="edited">(edited)</span>`)
+	assert.Contains(t, page, `<span class="mention">@bob</span>`)
+	assert.Contains(t, page, "<pre>exit status 1</pre>")
+	assert.Contains(t, page, `<span class="reaction" title=":eyes:">👀 2</span>`)
+	assert.Contains(t, page, `<a href="https://files.example.com/F1">deploy.log</a>`)
+	assert.Contains(t, page, "a &lt;b&gt; c")
+
+	// The reply is nested inside the parent's replies block
+	replies := strings.Index(page, `<div class="replies">`)
+	require.Positive(t, replies)
+	assert.Greater(t, strings.Index(page, "Rolled back"), replies)
+	assert.Greater(t, strings.Index(page, "carol"), strings.Index(page, "Rolled back"))
+}
+
+func TestHTMLAttachment_UnsafeLink(t *testing.T) {
+	tr := testTranscript()
+	got := tr.htmlAttachment(client.Attachment{Title: `Build "42" & <co>`, TitleLink: "javascript:alert(1)"})
+	assert.Equal(t, "<strong>Build &#34;42&#34; &amp; &lt;co&gt;</strong>", string(got))
+
+	got = tr.htmlAttachment(client.Attachment{Title: "Build 42", TitleLink: "https://ci.example.com/42", Text: "*passed*"})
+	assert.Equal(t, `<strong><a href="https://ci.example.com/42">Build 42</a></strong><br>`+"\n<strong>passed</strong>", string(got))
+}
+
+func TestNewThread(t *testing.T) {
+	assert.Equal(t, Thread{}, NewThread(nil))
+
+	th := NewThread([]client.Message{{TS: "1"}, {TS: "2"}, {TS: "3"}})
+	assert.Equal(t, "1", th.Message.TS)
+	assert.Len(t, th.Replies, 2)
+}
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -10,6 +11,10 @@ import (
 // Example: https://workspace.slack.com/archives/C123/p1234567890123456
 var slackURLTimestampRegex = regexp.MustCompile(`/p(\d{16})(?:\?|$)`)
 
+// permalinkPathRegex matches the path of a Slack message permalink and captures
+// the channel ID and the p-prefixed timestamp digits
+var permalinkPathRegex = regexp.MustCompile(`^/archives/([A-Z0-9]+)/p(\d{16})/?$`)
+
 var (
 	channelIDRegex = regexp.MustCompile(`^[CG][A-Z0-9]+$`)
 	userIDRegex    = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
@@ -72,6 +77,53 @@ func NormalizeTimestamp(input string) string {
 	return input
 }
 
+// MessageRef identifies a message by its channel and timestamp. ThreadTS is
+// set when a permalink points at a reply, and holds the thread's parent timestamp.
+type MessageRef struct {
+	Channel  string
+	TS       string
+	ThreadTS string
+}
+
+// ParsePermalink parses a Slack message permalink, such as
+// https://workspace.slack.com/archives/C123/p1234567890123456?thread_ts=1234567890.000100&cid=C123
+// It reports false if link isn't a message permalink.
+func ParsePermalink(link string) (MessageRef, bool) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return MessageRef{}, false
+	}
+
+	matches := permalinkPathRegex.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return MessageRef{}, false
+	}
+
+	ref := MessageRef{
+		Channel: matches[1],
+		TS:      matches[2][:10] + "." + matches[2][10:],
+	}
+	if threadTS := u.Query().Get("thread_ts"); timestampRegex.MatchString(threadTS) && threadTS != ref.TS {
+		ref.ThreadTS = threadTS
+	}
+	return ref, true
+}
+
+// MessageArgs reads a message from the start of command arguments, given
+// either as a permalink or as a channel followed by a timestamp, and returns
+// the arguments that follow it.
+func MessageArgs(args []string) (MessageRef, []string, error) {
+	if len(args) > 0 {
+		if ref, ok := ParsePermalink(args[0]); ok {
+			return ref, args[1:], nil
+		}
+	}
+	if len(args) < 2 {
+		return MessageRef{}, nil, fmt.Errorf("expected <channel> <timestamp> or a message permalink")
+	}
+	return MessageRef{Channel: args[0], TS: args[1]}, args[2:], nil
+}
+
 // Timestamp validates that the given string is a valid Slack message timestamp.
 // Timestamps are in the format "1234567890.123456".
 // Also accepts p-prefixed format and full Slack URLs, which are normalized first.
@@ -172,3 +172,74 @@ func TestLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name   string
+		link   string
+		want   MessageRef
+		wantOK bool
+	}{
+		{
+			"message",
+			"https://acme.slack.com/archives/C123ABC/p1700000000123456",
+			MessageRef{Channel: "C123ABC", TS: "1700000000.123456"},
+			true,
+		},
+		{
+			"thread reply",
+			"https://acme.slack.com/archives/C123ABC/p1700000000123456?thread_ts=1700000000.000100&cid=C123ABC",
+			MessageRef{Channel: "C123ABC", TS: "1700000000.123456", ThreadTS: "1700000000.000100"},
+			true,
+		},
+		{
+			"thread parent links to itself",
+			"https://acme.slack.com/archives/C123ABC/p1700000000123456?thread_ts=1700000000.123456",
+			MessageRef{Channel: "C123ABC", TS: "1700000000.123456"},
+			true,
+		},
+		{
+			"direct message",
+			"https://acme.slack.com/archives/D0123ABC/p1700000000123456",
+			MessageRef{Channel: "D0123ABC", TS: "1700000000.123456"},
+			true,
+		},
+		{"channel link", "https://acme.slack.com/archives/C123ABC", MessageRef{}, false},
+		{"not a URL", "general", MessageRef{}, false},
+		{"timestamp", "1700000000.123456", MessageRef{}, false},
+		{"p-prefixed timestamp", "p1700000000123456", MessageRef{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePermalink(tt.link)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParsePermalink(%q) = %+v, %v; want %+v, %v", tt.link, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMessageArgs(t *testing.T) {
+	link := "https://acme.slack.com/archives/C123/p1700000000123456"
+
+	ref, rest, err := MessageArgs([]string{link, "eyes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref.Channel != "C123" || ref.TS != "1700000000.123456" || len(rest) != 1 || rest[0] != "eyes" {
+		t.Errorf("unexpected result for permalink: %+v %v", ref, rest)
+	}
+
+	ref, rest, err = MessageArgs([]string{"general", "1700000000.123456", "eyes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref.Channel != "general" || ref.TS != "1700000000.123456" || len(rest) != 1 {
+		t.Errorf("unexpected result for channel and timestamp: %+v %v", ref, rest)
+	}
+
+	if _, _, err := MessageArgs([]string{"general"}); err == nil {
+		t.Error("expected an error for a channel without a timestamp")
+	}
+}